@@ -44,10 +44,28 @@ func WithEditorUI(materials ...worldTypes.MaterialI) RunnerOption {
 		e.keyboardInput.SetCallback(ebiten.KeyE, func() {
 			e.cursor.IncRadius()
 		})
-		// Register the "flip vertical gravity" keyboard callback
+		// Register the "flip gravity" keyboard callback
 		e.keyboardInput.SetCallback(ebiten.KeyZ, func() {
 			e.cursor.FlipGravity()
 		})
+		// Register the "rotate directional gravity" keyboard callbacks (a key being held keeps rotating)
+		e.keyboardInput.SetCallback(ebiten.KeyArrowLeft, func() {
+			e.cursor.RotateGravity(false)
+		})
+		e.keyboardInput.SetCallback(ebiten.KeyArrowRight, func() {
+			e.cursor.RotateGravity(true)
+		})
+		// Register the "add / clear radial gravity centers" keyboard callbacks
+		e.keyboardInput.SetCallback(ebiten.KeyP, func() {
+			e.cursor.AddGravityCenter()
+		})
+		e.keyboardInput.SetCallback(ebiten.KeyC, func() {
+			e.cursor.ClearGravityCenters()
+		})
+		// Register the "strike a lightning" keyboard callback (a bolt from the top at the mouse cursor column)
+		e.keyboardInput.SetCallback(ebiten.KeyL, func() {
+			e.cursor.StrikeLightning()
+		})
 
 		// Remove Particles tool
 		removeToggleTool := newRemoveToggleTile(e.cursor.UpdateMaterial)
@@ -84,6 +102,106 @@ func WithEditorUI(materials ...worldTypes.MaterialI) RunnerOption {
 			randomForceTool.Toggle()
 		})
 
+		// Map state overlay switch tool
+		var overlayNames []string
+		for _, overlayType := range worldTypes.AllOverlayTypes {
+			overlayNames = append(overlayNames, overlayType.String())
+		}
+		overlayTool := newCycleTile(
+			overlayNames,
+			func(idx int) {
+				e.cursor.SetOverlay(worldTypes.AllOverlayTypes[idx])
+			},
+		)
+		e.keyboardInput.SetCallback(ebiten.KeyO, func() {
+			overlayTool.Next()
+		})
+
+		// Graviton attraction mode switch tool
+		var gravitonModeNames []string
+		for _, mode := range worldTypes.AllGravitonModes {
+			gravitonModeNames = append(gravitonModeNames, mode.String())
+		}
+		gravitonModeTool := newCycleTile(
+			gravitonModeNames,
+			func(idx int) {
+				e.cursor.SetGravitonMode(worldTypes.AllGravitonModes[idx])
+			},
+		)
+		e.keyboardInput.SetCallback(ebiten.KeyG, func() {
+			gravitonModeTool.Next()
+		})
+
+		// Gravity mode switch tool
+		var gravityModeNames []string
+		for _, mode := range worldTypes.AllGravityModes {
+			gravityModeNames = append(gravityModeNames, mode.String())
+		}
+		gravityModeTool := newCycleTile(
+			gravityModeNames,
+			func(idx int) {
+				e.cursor.SetGravityMode(worldTypes.AllGravityModes[idx])
+			},
+		)
+		e.keyboardInput.SetCallback(ebiten.KeyV, func() {
+			gravityModeTool.Next()
+		})
+
+		// Nature preset (weather) switch tool
+		var naturePresetNames []string
+		for _, preset := range worldTypes.AllNaturePresets {
+			naturePresetNames = append(naturePresetNames, preset.String())
+		}
+		naturePresetTool := newCycleTile(
+			naturePresetNames,
+			func(idx int) {
+				e.cursor.SetNaturePreset(worldTypes.AllNaturePresets[idx])
+			},
+		)
+		e.keyboardInput.SetCallback(ebiten.KeyW, func() {
+			naturePresetTool.Next()
+		})
+
+		// Rigid body creation mode switch tool
+		bodyTool := newCycleTile(
+			[]string{"Body", "Rigid", "Pinned"},
+			func(idx int) {
+				e.cursor.SetBodyMode(idx > 0, idx == 2)
+			},
+		)
+		e.keyboardInput.SetCallback(ebiten.KeyB, func() {
+			bodyTool.Next()
+		})
+
+		// Particle bond type switch tool
+		var bondNames []string
+		for _, bondType := range worldTypes.AllBondTypes {
+			bondNames = append(bondNames, bondType.String())
+		}
+		bondTool := newCycleTile(
+			bondNames,
+			func(idx int) {
+				e.cursor.SetBondType(worldTypes.AllBondTypes[idx])
+			},
+		)
+		e.keyboardInput.SetCallback(ebiten.KeyR, func() {
+			bondTool.Next()
+		})
+
+		// Bug population view toggle tool
+		populationTool := newGenericToggleTile(
+			"Pop",
+			func() {
+				r.showPopulation = true
+			},
+			func() {
+				r.showPopulation = false
+			},
+		)
+		e.keyboardInput.SetCallback(ebiten.KeyT, func() {
+			populationTool.Toggle()
+		})
+
 		// Create Material tools and assign 1..9 keyboard input callbacks to them
 		for idx, m := range materials {
 			materialTool := newMaterialTile(m, func(m worldTypes.MaterialI) {
@@ -108,6 +226,13 @@ func WithEditorUI(materials ...worldTypes.MaterialI) RunnerOption {
 			removeToggleTool,
 			circleCursorTool,
 			randomForceTool,
+			overlayTool,
+			gravitonModeTool,
+			gravityModeTool,
+			naturePresetTool,
+			bodyTool,
+			bondTool,
+			populationTool,
 		)
 		e.toolTiles[0].OnClick(-1, -1)
 
@@ -152,6 +277,7 @@ func (e *editor) GetNextWorldAction() worldTypes.InputAction {
 func (e *editor) handleLeftClick() {
 	mouseX, mouseY, isPressed := e.mouseLeftInput.IsPressed()
 	if !isPressed {
+		e.cursor.OnRelease()
 		return
 	}
 
@@ -16,6 +16,8 @@ const (
 	cursorRadiusMin  = 5  // circle cursor min radius
 	cursorRadiusMax  = 80 // circle cursor max radius
 	cursorRadiusStep = 3  // circle cursor radius inc/dec step
+	//
+	gravityRotationStepDeg = 15.0 // directional gravity rotation step (per key press)
 )
 
 // cursorTool keeps the currently selected Material tool data and pending World input actions.
@@ -23,6 +25,12 @@ type cursorTool struct {
 	material           worldTypes.MaterialI   // current Material selected (nil if not)
 	radius             int                    // current circle type radius
 	applyForce         bool                   // if "apply random force" is toggled
+	bodyMode           bool                   // if set, groups existing Particles into a rigid body instead of creating new ones
+	bodyPinned         bool                   // if set, a new rigid body is pinned
+	bondType           worldTypes.BondType    // if set, new Particles are bonded within a stroke
+	strokeID           int                    // the current (or the last) continuous drawing ID
+	isStroking         bool                   // if the mouse button is being pressed
+	bodyStroke         []worldTypes.Position  // body mode stroke cursor Positions (grouped on the stroke end)
 	dotImage           *ebiten.Image          // dot type image
 	circleColor        color.Color            // current tools color
 	pendingWorldAction worldTypes.InputAction // the next World input action to apply (nil if none)
@@ -62,7 +70,21 @@ func (t *cursorTool) Draw(screen *ebiten.Image, drawOpts *ebiten.DrawImageOption
 }
 
 // OnPress generates a new World input action.
+// In the body mode, cursor Positions are collected till the stroke end.
 func (t *cursorTool) OnPress(mouseX, mouseY int) {
+	if !t.isStroking {
+		t.isStroking = true
+		t.strokeID++
+	}
+
+	if t.bodyMode {
+		pos := worldTypes.NewPosition(mouseX, mouseY)
+		if len(t.bodyStroke) == 0 || !t.bodyStroke[len(t.bodyStroke)-1].Equal(pos) {
+			t.bodyStroke = append(t.bodyStroke, pos)
+		}
+		return
+	}
+
 	if t.material != nil {
 		t.pendingWorldAction = worldTypes.CreateParticlesInputAction{
 			X:          mouseX,
@@ -70,6 +92,8 @@ func (t *cursorTool) OnPress(mouseX, mouseY int) {
 			Radius:     t.radius,
 			Material:   t.material,
 			ApplyForce: t.applyForce,
+			Bond:       t.bondType,
+			StrokeID:   t.strokeID,
 		}
 	} else {
 		t.pendingWorldAction = worldTypes.DeleteParticlesInputAction{
@@ -80,6 +104,21 @@ func (t *cursorTool) OnPress(mouseX, mouseY int) {
 	}
 }
 
+// OnRelease ends the current continuous drawing (stroke).
+// In the body mode, generates a new World input action for the whole stroke.
+func (t *cursorTool) OnRelease() {
+	if t.isStroking && t.bodyMode && len(t.bodyStroke) > 0 {
+		t.pendingWorldAction = worldTypes.CreateBodyInputAction{
+			Positions: t.bodyStroke,
+			Radius:    t.radius,
+			Pinned:    t.bodyPinned,
+		}
+	}
+
+	t.bodyStroke = nil
+	t.isStroking = false
+}
+
 // GetPendingWorldAction ...
 func (t *cursorTool) GetPendingWorldAction() worldTypes.InputAction {
 	if t.pendingWorldAction == nil {
@@ -129,6 +168,17 @@ func (t *cursorTool) DisableRandomForce() {
 	t.applyForce = false
 }
 
+// SetBodyMode enables / disables the "create rigid body" mode.
+func (t *cursorTool) SetBodyMode(enabled, pinned bool) {
+	t.bodyMode = enabled
+	t.bodyPinned = pinned
+}
+
+// SetBondType sets the bond type for new Particles painted within a stroke.
+func (t *cursorTool) SetBondType(bondType worldTypes.BondType) {
+	t.bondType = bondType
+}
+
 // IncRadius increments the circle radius for the circle mode.
 func (t *cursorTool) IncRadius() {
 	if t.radius == 1 {
@@ -157,3 +207,67 @@ func (t *cursorTool) DecRadius() {
 func (t *cursorTool) FlipGravity() {
 	t.pendingWorldAction = worldTypes.FlipGravityInputAction{}
 }
+
+// SetGravityMode generates a new World input action.
+func (t *cursorTool) SetGravityMode(mode worldTypes.GravityMode) {
+	t.pendingWorldAction = worldTypes.SetGravityModeInputAction{
+		Mode: mode,
+	}
+}
+
+// RotateGravity generates a new World input action.
+func (t *cursorTool) RotateGravity(clockwise bool) {
+	angle := pkg.DegToRadAngle(gravityRotationStepDeg)
+	if !clockwise {
+		angle = -angle
+	}
+
+	t.pendingWorldAction = worldTypes.RotateGravityInputAction{
+		Angle: angle,
+	}
+}
+
+// AddGravityCenter generates a new World input action (a new "planet" center at the mouse cursor).
+func (t *cursorTool) AddGravityCenter() {
+	mouseX, mouseY := ebiten.CursorPosition()
+
+	t.pendingWorldAction = worldTypes.AddGravityCenterInputAction{
+		X: mouseX,
+		Y: mouseY,
+	}
+}
+
+// ClearGravityCenters generates a new World input action.
+func (t *cursorTool) ClearGravityCenters() {
+	t.pendingWorldAction = worldTypes.ClearGravityCentersInputAction{}
+}
+
+// SetNaturePreset generates a new World input action.
+func (t *cursorTool) SetNaturePreset(preset worldTypes.NaturePreset) {
+	t.pendingWorldAction = worldTypes.SetNaturePresetInputAction{
+		Preset: preset,
+	}
+}
+
+// StrikeLightning generates a new World input action (a lightning bolt at the mouse cursor column).
+func (t *cursorTool) StrikeLightning() {
+	mouseX, _ := ebiten.CursorPosition()
+
+	t.pendingWorldAction = worldTypes.StrikeLightningInputAction{
+		X: mouseX,
+	}
+}
+
+// SetOverlay generates a new World input action.
+func (t *cursorTool) SetOverlay(overlayType worldTypes.OverlayType) {
+	t.pendingWorldAction = worldTypes.SetOverlayInputAction{
+		Overlay: overlayType,
+	}
+}
+
+// SetGravitonMode generates a new World input action.
+func (t *cursorTool) SetGravitonMode(mode worldTypes.GravitonMode) {
+	t.pendingWorldAction = worldTypes.SetGravitonModeInputAction{
+		Mode: mode,
+	}
+}
@@ -64,8 +64,15 @@ type toolBase struct {
 func (t *toolBase) Layout(screenWidth, screenHeight int) {
 	tileWidth, tileHeight := t.image.Size()
 
-	t.tileX = float64(screenWidth - tileWidth - toolTileOffsetRight)
-	t.tileY = float64(t.id*(tileHeight+toolTileMargin) + toolTileMargin)
+	// Tools are wrapped into columns (right to left) if they don't fit the screen height
+	rowsNum := (screenHeight - toolTileMargin) / (tileHeight + toolTileMargin)
+	if rowsNum < 1 {
+		rowsNum = 1
+	}
+	column, row := t.id/rowsNum, t.id%rowsNum
+
+	t.tileX = float64(screenWidth - (column+1)*(tileWidth+toolTileOffsetRight))
+	t.tileY = float64(row*(tileHeight+toolTileMargin) + toolTileMargin)
 
 	if t.text != "" {
 		t.textX = int(t.tileX) + toolTextOffsetLeft
@@ -241,6 +248,55 @@ func (t *genericToggleTile) Toggle() {
 	}
 }
 
+// cycleTile defines a tool that switches between a set of options.
+// The first option is considered as the OFF state.
+type cycleTile struct {
+	toolBase
+	options  []string
+	idx      int
+	callback func(idx int)
+}
+
+func newCycleTile(options []string, callback func(idx int)) *cycleTile {
+	t := cycleTile{
+		toolBase: toolBase{
+			id:        nextToolID(),
+			image:     ebiten.NewImage(toolTileWidth, toolTileHeight),
+			text:      options[0],
+			textColor: toggleToolFontColor,
+			toggled:   true,
+		},
+		options:  options,
+		callback: callback,
+	}
+	t.toggleOff()
+
+	return &t
+}
+
+func (t *cycleTile) OnClick(cursorX, cursorY int) bool {
+	if !t.isCursorOver(cursorX, cursorY) {
+		return false
+	}
+
+	t.Next()
+
+	return true
+}
+
+// Next switches to the next option.
+func (t *cycleTile) Next() {
+	t.idx = (t.idx + 1) % len(t.options)
+	t.text = t.options[t.idx]
+	if t.idx == 0 {
+		t.toggleOff()
+	} else {
+		t.toggleOn()
+	}
+
+	t.callback(t.idx)
+}
+
 // nextToolID returns the next unique tool ID.
 func nextToolID() int {
 	lastToolID++
@@ -31,6 +31,11 @@ type Runner struct {
 	tileSize     float64                       // the current Tile size relative to (screenWidth, screenHeight)
 	tilesCache   map[color.Color]*ebiten.Image // cached pixels
 	tileDrawOpts *ebiten.DrawImageOptions      // reused object to save some time on rendering
+	// Lightning flash state
+	flashBolts      [][]worldTypes.Position // the last lightning bolt paths
+	flashFramesLeft int                     // remaining flash frames (0 if there is no flash)
+	// Population view state
+	showPopulation bool // draw the Bug population panel
 	// External services
 	monitor *monitor.Keeper
 }
@@ -125,6 +130,9 @@ func (r *Runner) Draw(screen *ebiten.Image) {
 
 	// Render pixels
 	drawnPixels := r.drawTiles(screen)
+	r.drawAmbientLight(screen)
+	r.drawLightning(screen)
+	r.drawPopulation(screen)
 
 	// Render the editor
 	if r.editor != nil {
@@ -148,10 +156,14 @@ func (r *Runner) Draw(screen *ebiten.Image) {
 		}
 	}
 
-	ebitenutil.DebugPrint(screen, fmt.Sprintf("FPS: %.1f  Particles: %d  Wind: %s\n[%d, %d]",
+	season, ambientTemp := r.worldMap.Season()
+
+	ebitenutil.DebugPrint(screen, fmt.Sprintf("FPS: %.1f  Particles: %d  Wind: %s  Daylight: %.2f  %s: %.1f°C\n[%d, %d]",
 		fps,
 		drawnPixels,
 		globalWindStr,
+		r.worldMap.Daylight(),
+		season, ambientTemp,
 		r.mouseCoordToWorld(mouseX), r.mouseCoordToWorld(mouseY),
 	))
 }
@@ -180,29 +192,178 @@ func (r *Runner) Layout(outsideWidth, outsideHeight int) (screenWidth, screenHei
 	return outsideWidth, outsideHeight
 }
 
-// drawTiles iterates over all non-empty Tiles and draws them.
+// drawTiles iterates over all non-empty Tiles and draws them (the overlay is drawn on top of them).
 // Utilizes the image cache to save some FPSs.
 func (r *Runner) drawTiles(screen *ebiten.Image) int64 {
 	drawnPixels := int64(0)
 
-	r.worldMap.ExportState(func(tile worldTypes.TileI) {
-		tileImage, found := r.tilesCache[tile.Color()]
-		if !found {
-			tileImage = ebiten.NewImage(int(r.tileSize), int(r.tileSize))
-			tileImage.Fill(tile.Color())
-			r.tilesCache[tile.Color()] = tileImage
+	r.worldMap.ExportState(
+		func(tile worldTypes.TileI) {
+			r.drawTile(screen, tile)
+			drawnPixels++
+		},
+		func(tile worldTypes.TileI) {
+			r.drawTile(screen, tile)
+		},
+	)
+
+	return drawnPixels
+}
+
+// drawAmbientLight shades the World area depending on the ambient daylight level (a night is dark).
+func (r *Runner) drawAmbientLight(screen *ebiten.Image) {
+	const (
+		maxDarknessAlpha = 0xB0 // the darkest night shade alpha
+	)
+
+	darkness := 1.0 - r.worldMap.Daylight()
+	if darkness <= 0.0 {
+		return
+	}
+
+	mapWidth, mapHeight := r.worldMap.Size()
+	ebitenutil.DrawRect(
+		screen,
+		0, 0, float64(mapWidth)*r.tileSize, float64(mapHeight)*r.tileSize,
+		color.NRGBA{R: 0x00, G: 0x00, B: 0x18, A: uint8(darkness * maxDarknessAlpha)},
+	)
+}
+
+// drawLightning briefly flashes the World area and draws lightning bolts struck during the last state export.
+func (r *Runner) drawLightning(screen *ebiten.Image) {
+	const (
+		flashFrames   = 8    // flash duration [frames]
+		maxFlashAlpha = 0x90 // the brightest flash alpha
+		boltAlphaMin  = 0x60 // bolt alpha at the end of the flash
+	)
+
+	if bolts := r.worldMap.LightningBolts(); len(bolts) > 0 {
+		r.flashBolts = append(r.flashBolts[:0], bolts...)
+		r.flashFramesLeft = flashFrames
+	}
+	if r.flashFramesLeft <= 0 {
+		return
+	}
+
+	brightness := float64(r.flashFramesLeft) / flashFrames
+	r.flashFramesLeft--
+
+	mapWidth, mapHeight := r.worldMap.Size()
+	ebitenutil.DrawRect(
+		screen,
+		0, 0, float64(mapWidth)*r.tileSize, float64(mapHeight)*r.tileSize,
+		color.NRGBA{R: 0xFF, G: 0xFF, B: 0xF0, A: uint8(brightness * maxFlashAlpha)},
+	)
+
+	boltColor := color.NRGBA{R: 0xF0, G: 0xF0, B: 0xFF, A: uint8(boltAlphaMin + brightness*(0xFF-boltAlphaMin))}
+	for _, bolt := range r.flashBolts {
+		for _, pos := range bolt {
+			ebitenutil.DrawRect(screen, float64(pos.X)*r.tileSize, float64(pos.Y)*r.tileSize, r.tileSize, r.tileSize, boltColor)
 		}
+	}
+}
+
+// drawPopulation draws the population panel: Bug / Predator numbers history, the current Bug trait distributions (histograms) and trait mean history.
+func (r *Runner) drawPopulation(screen *ebiten.Image) {
+	const (
+		panelX, panelY = 10.0, 40.0 // panel top-left position (below the debug text)
+		panelWidth     = 420.0      // panel width
+		rowHeight      = 56.0       // per trait row height
+		rowPadding     = 18.0       // per trait row text height
+		histWidth      = 150.0      // histogram width
+		historyX       = 170.0      // mean history plot offset (from the panel left)
+		historyWidth   = 240.0      // mean history plot width
+	)
+
+	var (
+		panelColor    = color.NRGBA{R: 0x10, G: 0x10, B: 0x10, A: 0xC0}
+		histColor     = color.NRGBA{R: 0xE0, G: 0xE0, B: 0xE0, A: 0xFF}
+		historyColor  = color.NRGBA{R: 0x40, G: 0xE0, B: 0x60, A: 0xFF}
+		predatorColor = color.NRGBA{R: 0xE0, G: 0x40, B: 0x80, A: 0xFF}
+	)
 
-		tileDrawX, tileDrawY := float64(tile.X())*r.tileSize, float64(tile.Y())*r.tileSize
-		r.tileDrawOpts.GeoM.Reset()
-		r.tileDrawOpts.GeoM.Translate(tileDrawX, tileDrawY)
+	if !r.showPopulation {
+		return
+	}
 
-		screen.DrawImage(tileImage, r.tileDrawOpts)
+	history := r.worldMap.Population()
+	panelHeight := rowHeight*float64(len(worldTypes.AllBugTraits)+1) + 10
+	ebitenutil.DrawRect(screen, panelX, panelY, panelWidth, panelHeight, panelColor)
+	if len(history) == 0 {
+		ebitenutil.DebugPrintAt(screen, "Population: no data", panelX+5, panelY+5)
+		return
+	}
+	last := history[len(history)-1]
+
+	// plotHistory draws a history line within the row plot area ({valueFn} returns a value normalized to [0.0, 1.0])
+	plotHistory := func(plotY, plotHeight float64, lineColor color.Color, valueFn func(stats worldTypes.PopulationStats) float64) {
+		stepX := historyWidth / float64(len(history))
+		for i := 1; i < len(history); i++ {
+			x1, y1 := panelX+historyX+float64(i-1)*stepX, plotY+plotHeight*(1.0-valueFn(history[i-1]))
+			x2, y2 := panelX+historyX+float64(i)*stepX, plotY+plotHeight*(1.0-valueFn(history[i]))
+			ebitenutil.DrawLine(screen, x1, y1, x2, y2, lineColor)
+		}
+	}
 
-		drawnPixels++
+	// Population size row (predator-prey numbers)
+	rowY := panelY + 5
+	maxCount := 1
+	for _, stats := range history {
+		if stats.BugsCount > maxCount {
+			maxCount = stats.BugsCount
+		}
+		if stats.PredatorsCount > maxCount {
+			maxCount = stats.PredatorsCount
+		}
+	}
+	ebitenutil.DebugPrintAt(screen, fmt.Sprintf("Bugs: %d  Predators: %d  round: %d", last.BugsCount, last.PredatorsCount, last.Round), int(panelX+5), int(rowY))
+	plotHistory(rowY+rowPadding, rowHeight-rowPadding-5, historyColor, func(stats worldTypes.PopulationStats) float64 {
+		return float64(stats.BugsCount) / float64(maxCount)
+	})
+	plotHistory(rowY+rowPadding, rowHeight-rowPadding-5, predatorColor, func(stats worldTypes.PopulationStats) float64 {
+		return float64(stats.PredatorsCount) / float64(maxCount)
 	})
 
-	return drawnPixels
+	// Per trait rows
+	for _, trait := range worldTypes.AllBugTraits {
+		rowY += rowHeight
+		traitStats := last.BugTraits[trait]
+		minV, maxV := trait.Range()
+
+		ebitenutil.DebugPrintAt(screen, fmt.Sprintf("%s: %.2f [%.2f, %.2f]", trait, traitStats.Mean, traitStats.Min, traitStats.Max), int(panelX+5), int(rowY))
+
+		plotY, plotHeight := rowY+rowPadding, rowHeight-rowPadding-5
+		if last.BugsCount > 0 {
+			binWidth := histWidth / worldTypes.BugTraitHistogramBins
+			for bin, cnt := range traitStats.Histogram {
+				barHeight := plotHeight * float64(cnt) / float64(last.BugsCount)
+				ebitenutil.DrawRect(screen, panelX+5+float64(bin)*binWidth, plotY+plotHeight-barHeight, binWidth-1, barHeight, histColor)
+			}
+		}
+
+		plotHistory(plotY, plotHeight, historyColor, func(stats worldTypes.PopulationStats) float64 {
+			if stats.BugsCount == 0 {
+				return 0.0
+			}
+			return (stats.BugTraits[trait].Mean - minV) / (maxV - minV)
+		})
+	}
+}
+
+// drawTile draws a single Tile.
+func (r *Runner) drawTile(screen *ebiten.Image, tile worldTypes.TileI) {
+	tileImage, found := r.tilesCache[tile.Color()]
+	if !found {
+		tileImage = ebiten.NewImage(int(r.tileSize), int(r.tileSize))
+		tileImage.Fill(tile.Color())
+		r.tilesCache[tile.Color()] = tileImage
+	}
+
+	tileDrawX, tileDrawY := float64(tile.X())*r.tileSize, float64(tile.Y())*r.tileSize
+	r.tileDrawOpts.GeoM.Reset()
+	r.tileDrawOpts.GeoM.Translate(tileDrawX, tileDrawY)
+
+	screen.DrawImage(tileImage, r.tileDrawOpts)
 }
 
 // applyWorldAction passes through editor input to the World by type.
@@ -218,6 +379,18 @@ func (r *Runner) applyWorldAction(actionBz worldTypes.InputAction) {
 		action.Y = int(float64(action.Y) / r.tileSize)
 		action.Radius = int(float64(action.Radius) / r.tileSize)
 
+		r.worldMap.PushInputAction(action)
+	case worldTypes.CreateBodyInputAction:
+		positions := make([]worldTypes.Position, 0, len(action.Positions))
+		for _, pos := range action.Positions {
+			positions = append(positions, worldTypes.NewPosition(
+				int(float64(pos.X)/r.tileSize),
+				int(float64(pos.Y)/r.tileSize),
+			))
+		}
+		action.Positions = positions
+		action.Radius = int(float64(action.Radius) / r.tileSize)
+
 		r.worldMap.PushInputAction(action)
 	case worldTypes.DeleteParticlesInputAction:
 		action.X = int(float64(action.X) / r.tileSize)
@@ -227,6 +400,26 @@ func (r *Runner) applyWorldAction(actionBz worldTypes.InputAction) {
 		r.worldMap.PushInputAction(action)
 	case worldTypes.FlipGravityInputAction:
 		r.worldMap.PushInputAction(action)
+	case worldTypes.SetOverlayInputAction:
+		r.worldMap.PushInputAction(action)
+	case worldTypes.SetGravitonModeInputAction:
+		r.worldMap.PushInputAction(action)
+	case worldTypes.SetGravityModeInputAction:
+		r.worldMap.PushInputAction(action)
+	case worldTypes.RotateGravityInputAction:
+		r.worldMap.PushInputAction(action)
+	case worldTypes.AddGravityCenterInputAction:
+		action.X = int(float64(action.X) / r.tileSize)
+		action.Y = int(float64(action.Y) / r.tileSize)
+
+		r.worldMap.PushInputAction(action)
+	case worldTypes.ClearGravityCentersInputAction:
+		r.worldMap.PushInputAction(action)
+	case worldTypes.SetNaturePresetInputAction:
+		r.worldMap.PushInputAction(action)
+	case worldTypes.StrikeLightningInputAction:
+		action.X = r.mouseCoordToWorld(action.X)
+		r.worldMap.PushInputAction(action)
 	}
 }
 
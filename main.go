@@ -27,7 +27,8 @@ func main() {
 	worldMap, err := world.NewMap(
 		world.WithWidth(250),
 		world.WithHeight(250),
-		world.WithNatureEffects(),
+		world.WithNatureEffects(worldTypes.NewNatureConfig(worldTypes.NaturePresetDefault)),
+		world.WithWindField(worldTypes.DefaultWindConfig()),
 		//world.WithMonitor(monitorKeeper),
 	)
 	if err != nil {
@@ -57,11 +58,27 @@ func main() {
 		materials.NewAntiGraviton(), // 0
 		materials.NewSmoke(),
 		materials.NewSteam(),
+		materials.NewOil(),
+		materials.NewGunpowder(),
+		materials.NewTNT(),
+		materials.NewGlass(),
+		materials.NewSnow(),
+		materials.NewIce(),
+		materials.NewSeed(),
+		materials.NewLeaf(),
+		materials.NewPredator(),
+		materials.NewAnt(),
+		materials.NewFish(),
+		materials.NewAlgae(),
+		materials.NewDeadMatter(),
+		materials.NewSoil(),
+		materials.NewLava(),
+		materials.NewObsidian(),
 	}
 
 	runner, err := engine.NewRunner(
 		worldMap,
-		engine.WithScreenSize(1200, 1100),
+		engine.WithScreenSize(1300, 1100),
 		engine.WithEditorUI(materialsAll...),
 		//engine.WithMonitor(monitorKeeper),
 	)
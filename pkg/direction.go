@@ -88,27 +88,41 @@ func (d Direction) Angle() (angleRad float64) {
 	return 0
 }
 
+// Offset returns the corresponding grid step (a neighbour coordinates delta).
+func (d Direction) Offset() (dx, dy int) {
+	switch d {
+	case DirectionTop:
+		return 0, -1
+	case DirectionTopRight:
+		return 1, -1
+	case DirectionRight:
+		return 1, 0
+	case DirectionBottomRight:
+		return 1, 1
+	case DirectionBottom:
+		return 0, 1
+	case DirectionBottomLeft:
+		return -1, 1
+	case DirectionLeft:
+		return -1, 0
+	case DirectionTopLeft:
+		return -1, -1
+	}
+
+	return 0, 0
+}
+
 // Sector returns a set of Directions in a sector (close to {d} within depth).
 func (d Direction) Sector(depth uint) []Direction {
 	if depth >= 4 {
 		return AllDirections
 	}
 
-	normalizeDirection := func(dir Direction) Direction {
-		if dir < 0 {
-			return dir + 8
-		}
-		if dir > 7 {
-			return dir - 8
-		}
-		return dir
-	}
-
 	neighbours := make([]Direction, 0, depth*2+1)
 	neighbours = append(neighbours, d)
 	for i := 1; i <= int(depth); i++ {
-		neighbours = append(neighbours, normalizeDirection(d+Direction(i)))
-		neighbours = append(neighbours, normalizeDirection(d-Direction(i)))
+		neighbours = append(neighbours, d.Rotate(i))
+		neighbours = append(neighbours, d.Rotate(-i))
 	}
 
 	return neighbours
@@ -116,17 +130,26 @@ func (d Direction) Sector(depth uint) []Direction {
 
 // Next returns the next Direction (clockwise).
 func (d Direction) Next() Direction {
-	dNext := d + 1
-	if dNext > 7 {
-		dNext = 0
-	}
-
-	return dNext
+	return d.Rotate(1)
 }
 
 // Rotate180 returns the inverted Direction.
 func (d Direction) Rotate180() Direction {
-	return (d + 4) % 8
+	return d.Rotate(4)
+}
+
+// Rotate returns the Direction rotated by the number of 45 degree steps (clockwise for a positive one).
+func (d Direction) Rotate(steps int) Direction {
+	if d == DirectionNone {
+		return d
+	}
+
+	idx := (int(d-DirectionTop) + steps) % 8
+	if idx < 0 {
+		idx += 8
+	}
+
+	return DirectionTop + Direction(idx)
 }
 
 // Int ...
@@ -0,0 +1,79 @@
+package pkg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDirectionRotations(t *testing.T) {
+	type testCase struct {
+		dir       Direction
+		next      Direction
+		rotate180 Direction
+		sector1   []Direction
+	}
+
+	testCases := []testCase{
+		{
+			dir:       DirectionTop,
+			next:      DirectionTopRight,
+			rotate180: DirectionBottom,
+			sector1:   []Direction{DirectionTop, DirectionTopRight, DirectionTopLeft},
+		},
+		{
+			dir:       DirectionRight,
+			next:      DirectionBottomRight,
+			rotate180: DirectionLeft,
+			sector1:   []Direction{DirectionRight, DirectionBottomRight, DirectionTopRight},
+		},
+		{
+			dir:       DirectionBottomRight,
+			next:      DirectionBottom,
+			rotate180: DirectionTopLeft,
+			sector1:   []Direction{DirectionBottomRight, DirectionBottom, DirectionRight},
+		},
+		{
+			dir:       DirectionBottom,
+			next:      DirectionBottomLeft,
+			rotate180: DirectionTop,
+			sector1:   []Direction{DirectionBottom, DirectionBottomLeft, DirectionBottomRight},
+		},
+		{
+			dir:       DirectionTopLeft,
+			next:      DirectionTop,
+			rotate180: DirectionBottomRight,
+			sector1:   []Direction{DirectionTopLeft, DirectionTop, DirectionLeft},
+		},
+		{
+			dir:       DirectionNone,
+			next:      DirectionNone,
+			rotate180: DirectionNone,
+			sector1:   []Direction{DirectionNone, DirectionNone, DirectionNone},
+		},
+	}
+
+	for _, tc := range testCases {
+		if next := tc.dir.Next(); next != tc.next {
+			t.Errorf("%v.Next(): expected %v, got %v", tc.dir, tc.next, next)
+		}
+		if rotated := tc.dir.Rotate180(); rotated != tc.rotate180 {
+			t.Errorf("%v.Rotate180(): expected %v, got %v", tc.dir, tc.rotate180, rotated)
+		}
+		if sector := tc.dir.Sector(1); !reflect.DeepEqual(sector, tc.sector1) {
+			t.Errorf("%v.Sector(1): expected %v, got %v", tc.dir, tc.sector1, sector)
+		}
+	}
+
+	// Full rotation returns the same Direction and never produces DirectionNone
+	for _, dir := range AllDirections {
+		for steps := -9; steps <= 9; steps++ {
+			rotated := dir.Rotate(steps)
+			if rotated == DirectionNone {
+				t.Errorf("%v.Rotate(%d): unexpected DirectionNone", dir, steps)
+			}
+			if back := rotated.Rotate(-steps); back != dir {
+				t.Errorf("%v.Rotate(%d).Rotate(%d): expected %v, got %v", dir, steps, -steps, dir, back)
+			}
+		}
+	}
+}
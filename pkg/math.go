@@ -78,6 +78,11 @@ func FlipCoin() bool {
 	return rand.Intn(2) == 0
 }
 
+// RollChance returns true with the {chance} probability [0.0, 1.0].
+func RollChance(chance float64) bool {
+	return rand.Float64() < chance
+}
+
 // RollDice ...
 func RollDice(size int) bool {
 	if size < 2 {
@@ -0,0 +1,45 @@
+package pkg
+
+import (
+	"math"
+)
+
+// ValueNoise3 returns a smooth pseudo-random value in the [-1.0, 1.0] range for the 3D point.
+// Lattice points get random values (defined by the seed), values in between are interpolated (smoothstep),
+// so close points have close values. Z axis is usually used as a time.
+func ValueNoise3(x, y, z float64, seed int64) float64 {
+	x0, y0, z0 := math.Floor(x), math.Floor(y), math.Floor(z)
+	tx, ty, tz := smoothStep(x-x0), smoothStep(y-y0), smoothStep(z-z0)
+	ix, iy, iz := int64(x0), int64(y0), int64(z0)
+
+	lerp := func(a, b, t float64) float64 {
+		return a + (b-a)*t
+	}
+	lattice := func(dx, dy, dz int64) float64 {
+		return latticeValue(ix+dx, iy+dy, iz+dz, seed)
+	}
+
+	v00 := lerp(lattice(0, 0, 0), lattice(1, 0, 0), tx)
+	v10 := lerp(lattice(0, 1, 0), lattice(1, 1, 0), tx)
+	v01 := lerp(lattice(0, 0, 1), lattice(1, 0, 1), tx)
+	v11 := lerp(lattice(0, 1, 1), lattice(1, 1, 1), tx)
+
+	return lerp(lerp(v00, v10, ty), lerp(v01, v11, ty), tz)
+}
+
+// smoothStep returns the smoothstep curve value for t in [0.0, 1.0].
+func smoothStep(t float64) float64 {
+	return t * t * (3.0 - 2.0*t)
+}
+
+// latticeValue returns a pseudo-random value in the [-1.0, 1.0] range for the lattice point (splitmix64 hash).
+func latticeValue(x, y, z, seed int64) float64 {
+	h := uint64(seed) ^ uint64(x)*0x9E3779B97F4A7C15 ^ uint64(y)*0xC2B2AE3D27D4EB4F ^ uint64(z)*0x165667B19E3779F9
+	h ^= h >> 30
+	h *= 0xBF58476D1CE4E5B9
+	h ^= h >> 27
+	h *= 0x94D049BB133111EB
+	h ^= h >> 31
+
+	return float64(h>>11)/float64(1<<53)*2.0 - 1.0
+}
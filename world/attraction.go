@@ -0,0 +1,238 @@
+package world
+
+import (
+	"math"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+const (
+	// attractionTheta defines the Barnes-Hut approximation threshold (node size to distance ratio).
+	// A far node is approximated by its center of mass, the lower the value, the more precise (and slower) the result is.
+	attractionTheta = 0.5
+	// attractionSoftening defines the distance softening to avoid huge forces at a close range.
+	attractionSoftening = 2.0
+	// attractionMinForce defines the min force Vector magnitude to apply.
+	attractionMinForce = 0.001
+	// attractionMaxForce defines the max force Vector magnitude applied per processing round.
+	attractionMaxForce = 2.0
+	// attractionMassiveParticleMin defines the min ordinary Particle mass to attract others (the n-body mode).
+	attractionMassiveParticleMin = 100.0
+	// attractionMassiveParticleK defines the ordinary Particle attraction strength per mass unit (the n-body mode).
+	attractionMassiveParticleK = 0.05
+	// attractionMaxDepth defines the max tree depth (guards against an infinite split).
+	attractionMaxDepth = 32
+)
+
+type (
+	// attractionNode is a Barnes-Hut quadtree node keeping attraction sources within a square area.
+	// Attracting and repelling sources are accumulated separately (their sum might be zero).
+	attractionNode struct {
+		x0, y0, size   float64            // square area
+		posStrength    float64            // attracting sources total strength
+		posX, posY     float64            // attracting sources strength weighted position sums
+		negStrength    float64            // repelling sources total strength (negative)
+		negX, negY     float64            // repelling sources strength weighted position sums
+		children       [4]*attractionNode // sub-areas (nil for a leaf)
+		source         *attractionSource  // leaf source (nil if empty or for an internal node)
+		hasManySources bool               // internal node flag
+		depth          int                // tree level
+	}
+
+	// attractionSource defines a single attracting / repelling Particle.
+	attractionSource struct {
+		particleID uint64
+		x, y       float64
+		strength   float64
+	}
+)
+
+// buildAttractionTree rebuilds the attraction sources tree for the inverse-square Graviton modes.
+// Sources are Particles with a Material attraction strength and massive ordinary Particles for the n-body mode.
+func (m *Map) buildAttractionTree() {
+	m.attractionTree = nil
+	if m.gravitonMode == types.GravitonModeCircle {
+		return
+	}
+
+	if m.monitor != nil {
+		defer m.monitor.TrackOpDuration("Map.buildAttractionTree")()
+	}
+
+	size := float64(m.width)
+	if m.height > m.width {
+		size = float64(m.height)
+	}
+	root := &attractionNode{size: size}
+
+	hasSources := false
+	m.iterateNonEmptyTiles(func(tile *types.Tile) {
+		strength := m.attractionStrength(tile.Particle)
+		if strength == 0.0 {
+			return
+		}
+
+		root.insert(&attractionSource{
+			particleID: tile.Particle.ID(),
+			x:          float64(tile.Pos.X),
+			y:          float64(tile.Pos.Y),
+			strength:   strength,
+		})
+		hasSources = true
+	})
+
+	if hasSources {
+		m.attractionTree = root
+	}
+}
+
+// attractionStrength returns the Particle attraction strength (0.0 if it is not a source).
+func (m *Map) attractionStrength(particle *types.Particle) float64 {
+	material := particle.Material()
+	if strength := material.Attraction(); strength != 0.0 {
+		return strength
+	}
+
+	if m.gravitonMode != types.GravitonModeNBody || material.IsFlagged(types.MaterialFlagIsUnmovable) {
+		return 0.0
+	}
+	if mass := material.Mass(); mass >= attractionMassiveParticleMin {
+		return mass * attractionMassiveParticleK
+	}
+
+	return 0.0
+}
+
+// attractionForce returns the attraction force Vector for a movable Particle.
+// Returns false if there is nothing to apply.
+func (m *Map) attractionForce(tile *types.Tile) (pkg.Vector, bool) {
+	if m.attractionTree == nil || tile.Particle.Material().IsFlagged(types.MaterialFlagIsUnmovable) {
+		return pkg.Vector{}, false
+	}
+
+	x, y := float64(tile.Pos.X), float64(tile.Pos.Y)
+	forceX, forceY := m.attractionTree.force(x, y, tile.Particle.ID())
+
+	mag := math.Hypot(forceX, forceY)
+	if mag < attractionMinForce {
+		return pkg.Vector{}, false
+	}
+
+	return pkg.NewVectorByCoordinates(math.Min(mag, attractionMaxForce), 0, 0, forceX, forceY), true
+}
+
+// insert adds a new source to the node (splitting a leaf if needed).
+func (n *attractionNode) insert(source *attractionSource) {
+	n.addStrength(source)
+
+	// Empty leaf
+	if !n.hasManySources && n.source == nil {
+		n.source = source
+		return
+	}
+
+	// Leaf with a source: convert to an internal node
+	if !n.hasManySources {
+		if n.depth >= attractionMaxDepth {
+			return
+		}
+
+		prevSource := n.source
+		n.source = nil
+		n.hasManySources = true
+		n.child(prevSource).insert(prevSource)
+	}
+
+	n.child(source).insert(source)
+}
+
+// addStrength accumulates the source strength.
+func (n *attractionNode) addStrength(source *attractionSource) {
+	if source.strength > 0.0 {
+		n.posStrength += source.strength
+		n.posX += source.strength * source.x
+		n.posY += source.strength * source.y
+	} else {
+		n.negStrength += source.strength
+		n.negX += source.strength * source.x
+		n.negY += source.strength * source.y
+	}
+}
+
+// child returns the sub-area node for the source (creating it if needed).
+func (n *attractionNode) child(source *attractionSource) *attractionNode {
+	halfSize := n.size / 2.0
+
+	idx := 0
+	x0, y0 := n.x0, n.y0
+	if source.x >= n.x0+halfSize {
+		idx += 1
+		x0 += halfSize
+	}
+	if source.y >= n.y0+halfSize {
+		idx += 2
+		y0 += halfSize
+	}
+
+	if n.children[idx] == nil {
+		n.children[idx] = &attractionNode{
+			x0:    x0,
+			y0:    y0,
+			size:  halfSize,
+			depth: n.depth + 1,
+		}
+	}
+
+	return n.children[idx]
+}
+
+// force returns the total attraction force at the position.
+// {excludeID} source is skipped (a source doesn't attract itself).
+func (n *attractionNode) force(x, y float64, excludeID uint64) (float64, float64) {
+	// Leaf
+	if !n.hasManySources {
+		if n.source == nil || n.source.particleID == excludeID {
+			return 0.0, 0.0
+		}
+		return attractionSourceForce(x, y, n.source.x, n.source.y, n.source.strength)
+	}
+
+	// Far enough internal node: approximate by the centers of mass
+	centerX, centerY := n.x0+n.size/2.0, n.y0+n.size/2.0
+	if dist := math.Hypot(centerX-x, centerY-y); dist > 0.0 && n.size/dist < attractionTheta {
+		var forceX, forceY float64
+		if n.posStrength != 0.0 {
+			fx, fy := attractionSourceForce(x, y, n.posX/n.posStrength, n.posY/n.posStrength, n.posStrength)
+			forceX, forceY = forceX+fx, forceY+fy
+		}
+		if n.negStrength != 0.0 {
+			fx, fy := attractionSourceForce(x, y, n.negX/n.negStrength, n.negY/n.negStrength, n.negStrength)
+			forceX, forceY = forceX+fx, forceY+fy
+		}
+		return forceX, forceY
+	}
+
+	// Close internal node: go deeper
+	var forceX, forceY float64
+	for _, child := range n.children {
+		if child == nil {
+			continue
+		}
+		fx, fy := child.force(x, y, excludeID)
+		forceX, forceY = forceX+fx, forceY+fy
+	}
+
+	return forceX, forceY
+}
+
+// attractionSourceForce returns the inverse-square falloff force towards the source (away from it for a negative strength).
+func attractionSourceForce(x, y, sourceX, sourceY, strength float64) (float64, float64) {
+	dx, dy := sourceX-x, sourceY-y
+	distSq := dx*dx + dy*dy + attractionSoftening*attractionSoftening
+	dist := math.Sqrt(distSq)
+
+	k := strength / distSq / dist
+
+	return k * dx, k * dy
+}
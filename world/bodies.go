@@ -0,0 +1,420 @@
+package world
+
+import (
+	"math"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+const (
+	// bodyMinMembers defines the min number of Particles to create a rigid body.
+	bodyMinMembers = 2
+	// bodyRestitution defines the rigid body velocity restitution coefficient on collision.
+	bodyRestitution = 0.2
+	// bodyAngularDamping defines the rigid body angular velocity damping per processing round.
+	bodyAngularDamping = 0.99
+	// bodyFriction defines the rigid body tangential velocity reduction on a contact.
+	bodyFriction = 0.3
+	// bodyContactTorqueK defines the contact impulse to angular velocity conversion coefficient.
+	bodyContactTorqueK = 0.5
+	// bodyMaxStepsNum defines the max number of sub-steps per processing round (fast body movement).
+	bodyMaxStepsNum = 10
+)
+
+type (
+	// rigidBody keeps a group of Particles that move and rotate as one unit.
+	rigidBody struct {
+		id      int
+		members []bodyMember // grouped Particles
+		pinned  bool         // if set, body doesn't move, but rotates around its center
+		// Mass properties
+		mass    float64 // total mass
+		inertia float64 // moment of inertia around the center
+		// State
+		centerX, centerY float64 // center position
+		angle            float64 // rotation angle [rad]
+		velX, velY       float64 // linear velocity
+		angVel           float64 // angular velocity [rad per round]
+	}
+
+	// bodyMember defines a single rigid body Particle.
+	bodyMember struct {
+		particleID       uint64
+		mass             float64
+		offsetX, offsetY int // Position relative to the body center with zero rotation
+	}
+)
+
+// createBody groups the Tiles into a rigid body.
+// Body center is the closest to the center of mass Tile.
+func (m *Map) createBody(tiles []*types.Tile, pinned bool) {
+	if len(tiles) < bodyMinMembers {
+		return
+	}
+
+	var massSum, massX, massY float64
+	for _, tile := range tiles {
+		mass := tile.Particle.Material().Mass()
+		massSum += mass
+		massX += mass * float64(tile.Pos.X)
+		massY += mass * float64(tile.Pos.Y)
+	}
+	centerX, centerY := math.Round(massX/massSum), math.Round(massY/massSum)
+
+	m.lastBodyID++
+	body := &rigidBody{
+		id:      m.lastBodyID,
+		pinned:  pinned,
+		mass:    massSum,
+		centerX: centerX,
+		centerY: centerY,
+	}
+	for _, tile := range tiles {
+		member := bodyMember{
+			particleID: tile.Particle.ID(),
+			mass:       tile.Particle.Material().Mass(),
+			offsetX:    tile.Pos.X - int(centerX),
+			offsetY:    tile.Pos.Y - int(centerY),
+		}
+		body.members = append(body.members, member)
+		body.inertia += member.mass * float64(member.offsetX*member.offsetX+member.offsetY*member.offsetY)
+
+		tile.Particle.SetBodyID(body.id)
+		tile.Particle.SetForce(pkg.NewVector(0, 0))
+	}
+	body.inertia = math.Max(body.inertia, massSum)
+
+	m.bodies[body.id] = body
+}
+
+// dissolveBody breaks the rigid body apart.
+// Remaining Particles become independent keeping the body velocity at their Positions.
+func (m *Map) dissolveBody(body *rigidBody) {
+	for _, member := range body.members {
+		tile, found := m.particles[member.particleID]
+		if !found || tile.Particle.BodyID() != body.id {
+			continue
+		}
+
+		rX, rY := float64(tile.Pos.X)-body.centerX, float64(tile.Pos.Y)-body.centerY
+		velX, velY := body.velX-body.angVel*rY, body.velY+body.angVel*rX
+		tile.Particle.SetBodyID(0)
+		tile.Particle.SetForce(pkg.NewVectorByCoordinates(math.Hypot(velX, velY), 0, 0, velX, velY))
+	}
+
+	delete(m.bodies, body.id)
+}
+
+// processBodies moves and rotates all rigid bodies.
+// Collision impulses are collected from the member Particle forces (set by collision Actions).
+// Body breaks apart if any of its members is destroyed / replaced / moved by someone else.
+func (m *Map) processBodies() {
+	if m.monitor != nil {
+		defer m.monitor.TrackOpDuration("Map.processBodies")()
+	}
+
+	for _, body := range m.bodies {
+		memberTiles, ok := m.getBodyTiles(body)
+		if !ok {
+			m.dissolveBody(body)
+			continue
+		}
+
+		m.applyBodyImpulses(body, memberTiles)
+		m.stepBody(body, memberTiles)
+	}
+}
+
+// getBodyTiles returns the current member Tiles.
+// Returns false if the body integrity is broken.
+func (m *Map) getBodyTiles(body *rigidBody) ([]*types.Tile, bool) {
+	tiles := make([]*types.Tile, 0, len(body.members))
+	for _, member := range body.members {
+		tile, found := m.particles[member.particleID]
+		if !found || tile.Particle.BodyID() != body.id {
+			return nil, false
+		}
+
+		expectedPos := body.memberPosition(member, body.centerX, body.centerY, body.angle)
+		if !tile.Pos.Equal(expectedPos) {
+			return nil, false
+		}
+		tiles = append(tiles, tile)
+	}
+
+	return tiles, true
+}
+
+// applyBodyImpulses converts member force Vectors to the body linear and angular velocities (dropping member forces).
+func (m *Map) applyBodyImpulses(body *rigidBody, memberTiles []*types.Tile) {
+	for i, tile := range memberTiles {
+		forceVec := tile.Particle.ForceVector()
+		if forceVec.IsZero() {
+			continue
+		}
+		tile.Particle.SetForce(pkg.NewVector(0, 0))
+
+		member := body.members[i]
+		impulseX, impulseY := member.mass*forceVec.X(), member.mass*forceVec.Y()
+		if !body.pinned {
+			body.velX += impulseX / body.mass
+			body.velY += impulseY / body.mass
+		}
+
+		rX, rY := float64(tile.Pos.X)-body.centerX, float64(tile.Pos.Y)-body.centerY
+		body.angVel += (rX*impulseY - rY*impulseX) / body.inertia
+	}
+}
+
+// stepBody moves and rotates the body by its velocities checking the grid collisions.
+// Movement is split into sub-steps (one Tile at most) to avoid passing through obstacles.
+func (m *Map) stepBody(body *rigidBody, memberTiles []*types.Tile) {
+	// Gravity (local one at the body center)
+	gravityVec := m.gravity.at(int(math.Round(body.centerX)), int(math.Round(body.centerY)))
+	if !body.pinned {
+		body.velX += gravityVec.X()
+		body.velY += gravityVec.Y()
+	}
+	body.angVel *= bodyAngularDamping
+
+	// Define the number of sub-steps
+	maxOffset := 0.0
+	for _, member := range body.members {
+		maxOffset = math.Max(maxOffset, math.Hypot(float64(member.offsetX), float64(member.offsetY)))
+	}
+	stepsNum := math.Ceil(math.Max(math.Max(math.Abs(body.velX), math.Abs(body.velY)), math.Abs(body.angVel)*maxOffset))
+	stepsNum = math.Min(math.Max(stepsNum, 1.0), bodyMaxStepsNum)
+
+	centerX, centerY, angle := body.centerX, body.centerY, body.angle
+	for step := 0; step < int(stepsNum); step++ {
+		nextX, nextY, nextAngle := centerX+body.velX/stepsNum, centerY+body.velY/stepsNum, angle+body.angVel/stepsNum
+		if body.pinned {
+			nextX, nextY = centerX, centerY
+		}
+
+		// Full movement
+		if m.isBodyPlacementFree(body, nextX, nextY, nextAngle) {
+			centerX, centerY, angle = nextX, nextY, nextAngle
+			continue
+		}
+
+		// Contact: convert the blocked movement to a torque (the body tips over the contact point)
+		m.applyBodyContactTorque(body, nextX, nextY, nextAngle)
+
+		// Find a partial movement (blocked axes are reflected)
+		candidates := [][3]float64{
+			{nextX, centerY, nextAngle},
+			{centerX, nextY, nextAngle},
+			{centerX, centerY, nextAngle},
+			{nextX, nextY, angle},
+			{nextX, centerY, angle},
+			{centerX, nextY, angle},
+		}
+		found := false
+		for _, candidate := range candidates {
+			if m.isBodyPlacementFree(body, candidate[0], candidate[1], candidate[2]) {
+				centerX, centerY, angle = candidate[0], candidate[1], candidate[2]
+				found = true
+				break
+			}
+		}
+
+		if !found || centerX != nextX {
+			body.velX = -body.velX * bodyRestitution
+		}
+		if !found || centerY != nextY {
+			body.velY = -body.velY * bodyRestitution
+
+			// Tilted body slides down along its bottom face (with friction)
+			if !body.pinned {
+				body.velX = body.velX*(1.0-bodyFriction) + gravityVec.Y()*math.Sin(restAngle(angle))
+			}
+		}
+		if !found || angle != nextAngle {
+			body.angVel = -body.angVel * bodyRestitution
+		}
+		break
+	}
+
+	if !m.placeBody(body, memberTiles, centerX, centerY, angle) {
+		body.velX, body.velY = -body.velX*bodyRestitution, -body.velY*bodyRestitution
+		body.angVel = -body.angVel * bodyRestitution
+	}
+}
+
+// applyBodyContactTorque alters the body angular velocity based on members that can't be placed at the next state.
+// Each blocked member gets an impulse opposite to the body velocity.
+func (m *Map) applyBodyContactTorque(body *rigidBody, nextX, nextY, nextAngle float64) {
+	if body.pinned {
+		return
+	}
+
+	torque := 0.0
+	for _, member := range body.members {
+		pos := body.memberPosition(member, nextX, nextY, nextAngle)
+		if m.isBodyPositionFree(body, member, pos) {
+			continue
+		}
+
+		rX, rY := float64(pos.X)-nextX, float64(pos.Y)-nextY
+		torque += member.mass * (rX*(-body.velY) - rY*(-body.velX))
+	}
+	body.angVel += bodyContactTorqueK * torque / body.inertia
+}
+
+// isBodyPlacementFree checks if all body members can be placed at the specified state.
+func (m *Map) isBodyPlacementFree(body *rigidBody, centerX, centerY, angle float64) bool {
+	for _, member := range body.members {
+		if !m.isBodyPositionFree(body, member, body.memberPosition(member, centerX, centerY, angle)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isBodyPositionFree checks if the Position is empty, occupied by the same body member or by a Particle the member can push.
+func (m *Map) isBodyPositionFree(body *rigidBody, member bodyMember, pos types.Position) bool {
+	if !m.isPositionValid(pos.X, pos.Y) {
+		return false
+	}
+
+	tile := m.getTile(pos.X, pos.Y)
+	if !tile.HasParticle() || tile.Particle.BodyID() == body.id {
+		return true
+	}
+
+	return isBodyPushable(tile.Particle, member)
+}
+
+// placeBody moves member Particles to the new body state Positions pushing away lighter movable Particles.
+// Returns false if pushed Particles can't be moved (the body state is not changed).
+func (m *Map) placeBody(body *rigidBody, memberTiles []*types.Tile, centerX, centerY, angle float64) bool {
+	// Check if the Positions have changed
+	newPositions := make(map[types.Position]bool, len(body.members))
+	memberPositions := make([]types.Position, len(body.members))
+	isChanged := false
+	for i, member := range body.members {
+		memberPositions[i] = body.memberPosition(member, centerX, centerY, angle)
+		newPositions[memberPositions[i]] = true
+		if !memberPositions[i].Equal(memberTiles[i].Pos) {
+			isChanged = true
+		}
+	}
+	if !isChanged {
+		body.centerX, body.centerY, body.angle = centerX, centerY, angle
+		return true
+	}
+
+	// Find new Positions for Particles to push away
+	pushedTiles := make(map[*types.Tile]types.Position)
+	pushTargets := make(map[types.Position]bool)
+	for i, pos := range memberPositions {
+		tile := m.getTile(pos.X, pos.Y)
+		if !tile.HasParticle() || tile.Particle.BodyID() == body.id {
+			continue
+		}
+		if !isBodyPushable(tile.Particle, body.members[i]) {
+			return false
+		}
+
+		pushPos, found := m.findBodyPushPosition(body.id, pos, centerX, centerY, newPositions, pushTargets)
+		if !found {
+			return false
+		}
+		pushedTiles[tile] = pushPos
+		pushTargets[pushPos] = true
+	}
+
+	// Detach all members first, since the new Positions might overlap with the old ones
+	particles := make([]*types.Particle, len(memberTiles))
+	for i, tile := range memberTiles {
+		particles[i] = tile.Particle
+		tile.Particle = nil
+	}
+	for tile, pushPos := range pushedTiles {
+		m.moveTile(tile, pushPos)
+	}
+	for i, particle := range particles {
+		tile := m.getTile(memberPositions[i].X, memberPositions[i].Y)
+		tile.Particle = particle
+		m.particles[particle.ID()] = tile
+	}
+	body.centerX, body.centerY, body.angle = centerX, centerY, angle
+
+	return true
+}
+
+// findBodyPushPosition searches for the closest empty Position (away from the body center) to push a Particle to.
+// Positions occupied by the body (new ones) and other pushed Particles are skipped.
+// Occupied Tiles are skipped too, except the ones held by the body members (they are detached before the push).
+func (m *Map) findBodyPushPosition(bodyID int, pos types.Position, centerX, centerY float64, bodyPositions, pushedPositions map[types.Position]bool) (types.Position, bool) {
+	const maxRadius = 2
+
+	for r := 1; r <= maxRadius; r++ {
+		var bestPos types.Position
+		bestDist, found := -1.0, false
+		for dx := -r; dx <= r; dx++ {
+			for dy := -r; dy <= r; dy++ {
+				x, y := pos.X+dx, pos.Y+dy
+				if pkg.AbsInt(dx) != r && pkg.AbsInt(dy) != r {
+					continue
+				}
+
+				candidatePos := types.NewPosition(x, y)
+				if !m.isPositionValid(x, y) || bodyPositions[candidatePos] || pushedPositions[candidatePos] {
+					continue
+				}
+				if tile := m.getTile(x, y); tile.HasParticle() && tile.Particle.BodyID() != bodyID {
+					continue
+				}
+
+				if dist := math.Hypot(float64(x)-centerX, float64(y)-centerY); dist > bestDist {
+					bestPos, bestDist, found = candidatePos, dist, true
+				}
+			}
+		}
+		if found {
+			return bestPos, true
+		}
+	}
+
+	return types.Position{}, false
+}
+
+// isBodyPushable checks if a rigid body member can push the Particle away.
+func isBodyPushable(particle *types.Particle, member bodyMember) bool {
+	material := particle.Material()
+	if particle.BodyID() != 0 || material.IsFlagged(types.MaterialFlagIsUnmovable) || material.Type() == types.MaterialTypeBorder {
+		return false
+	}
+
+	return material.Mass() < member.mass
+}
+
+// memberPosition returns the member Position for the specified body state.
+// Rotation is done by three shears, which is a bijection on the grid (no members overlap, no holes).
+func (b *rigidBody) memberPosition(member bodyMember, centerX, centerY, angle float64) types.Position {
+	x, y := member.offsetX, member.offsetY
+
+	// Quarter turns are exact
+	quarterTurns := int(math.Round(angle / pkg.Rad90))
+	for i := 0; i < ((quarterTurns%4)+4)%4; i++ {
+		x, y = -y, x
+	}
+
+	// The rest angle is within [-Pi/4, Pi/4]
+	shearAngle := restAngle(angle)
+	shearX, shearY := -math.Tan(shearAngle/2.0), math.Sin(shearAngle)
+	x += int(math.Round(shearX * float64(y)))
+	y += int(math.Round(shearY * float64(x)))
+	x += int(math.Round(shearX * float64(y)))
+
+	return types.NewPosition(int(math.Round(centerX))+x, int(math.Round(centerY))+y)
+}
+
+// restAngle returns the angle left after the closest quarter turn (within [-Pi/4, Pi/4]).
+func restAngle(angle float64) float64 {
+	return angle - math.Round(angle/pkg.Rad90)*pkg.Rad90
+}
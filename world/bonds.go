@@ -0,0 +1,291 @@
+package world
+
+import (
+	"math"
+	"sort"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+const (
+	// bondSolverIterations defines the number of constraint solver passes per processing round.
+	bondSolverIterations = 20
+	// bondRopeStiffness defines the rope bond constraint error share corrected per solver pass.
+	bondRopeStiffness = 1.0
+	// bondSpringStiffness defines the spring bond constraint error share corrected per solver pass.
+	bondSpringStiffness = 0.3
+	// bondBreakStretchK defines the max bond length to rest length ratio (breaks if exceeded).
+	bondBreakStretchK = 4.0
+	// bondBreakMinStretch defines the min bond stretch distance to break (for short bonds).
+	bondBreakMinStretch = 3.0
+	// bondBurnTemperatureK defines the flammable Particle ignition temperature share its bonds burn at.
+	bondBurnTemperatureK = 0.5
+)
+
+// bondConstraint keeps a bond solver state for a pair of bonded Particles.
+type bondConstraint struct {
+	tile1, tile2       *types.Tile
+	invMass1, invMass2 float64
+	bond               types.ParticleBond
+}
+
+// createBond bonds two Particles with the distance constraint.
+func (m *Map) createBond(tile1, tile2 *types.Tile, bond types.ParticleBond) {
+	if tile1.Particle.ID() == tile2.Particle.ID() {
+		return
+	}
+
+	tile1.Particle.AddBond(tile2.Particle.ID(), bond)
+	tile2.Particle.AddBond(tile1.Particle.ID(), bond)
+	m.bondedParticles[tile1.Particle.ID()] = true
+	m.bondedParticles[tile2.Particle.ID()] = true
+}
+
+// removeBond breaks a bond from both sides (the other Particle might be gone already).
+func (m *Map) removeBond(particle *types.Particle, bondParticleID uint64) {
+	particle.RemoveBond(bondParticleID)
+	if tile, found := m.particles[bondParticleID]; found {
+		tile.Particle.RemoveBond(particle.ID())
+	}
+}
+
+// buildBondActions checks the Tile's Particle bonds and returns Actions to break the overstretched / burning ones.
+// Each bond is checked once (by the Particle with the lower ID).
+func (m *Map) buildBondActions(tile *types.Tile) []types.Action {
+	if !tile.Particle.HasBonds() {
+		return nil
+	}
+
+	var actions []types.Action
+	for bondPID, bond := range tile.Particle.Bonds() {
+		if bondPID < tile.Particle.ID() {
+			continue
+		}
+
+		bondTile, found := m.particles[bondPID]
+		if !found {
+			continue
+		}
+
+		length := math.Hypot(float64(bondTile.Pos.X-tile.Pos.X), float64(bondTile.Pos.Y-tile.Pos.Y))
+		isOverstretched := length > math.Max(bond.RestLength*bondBreakStretchK, bond.RestLength+bondBreakMinStretch)
+		if isOverstretched || m.isBondBurning(tile) || m.isBondBurning(bondTile) {
+			actions = append(actions, types.NewBondRemove(tile.Pos, tile.Particle.ID(), bondPID))
+		}
+	}
+
+	return actions
+}
+
+// isBondBurning checks if the flammable Particle is hot enough to burn its bonds.
+func (m *Map) isBondBurning(tile *types.Tile) bool {
+	material := tile.Particle.Material()
+	if !material.IsFlagged(types.MaterialFlagIsFlammable) {
+		return false
+	}
+
+	for _, transition := range material.Thermal().Transitions {
+		if transition.Change != types.MaterialPhaseChangeIgnite {
+			continue
+		}
+
+		return m.heat.Get(tile.Pos.X, tile.Pos.Y) >= transition.Temperature*bondBurnTemperatureK
+	}
+
+	return false
+}
+
+// processBonds solves bond distance constraints altering the bonded Particles force Vectors.
+// Solver works with the next round Particle Positions predicted by their force Vectors (applied before), so
+// a bonded Particle is pulled (pushed) to the rest length distance on its next move.
+// Broken bonds (a bonded Particle is gone) are dropped.
+func (m *Map) processBonds() {
+	if m.monitor != nil {
+		defer m.monitor.TrackOpDuration("Map.processBonds")()
+	}
+
+	// Collect constraints (each bond once)
+	var constraints []bondConstraint
+	for pid := range m.bondedParticles {
+		tile, found := m.particles[pid]
+		if !found || !tile.Particle.HasBonds() {
+			delete(m.bondedParticles, pid)
+			continue
+		}
+
+		for bondPID, bond := range tile.Particle.Bonds() {
+			bondTile, found := m.particles[bondPID]
+			if !found {
+				tile.Particle.RemoveBond(bondPID)
+				continue
+			}
+			if bondPID < pid {
+				continue
+			}
+
+			c := bondConstraint{
+				tile1:    tile,
+				tile2:    bondTile,
+				invMass1: bondInvMass(tile.Particle),
+				invMass2: bondInvMass(bondTile.Particle),
+				bond:     bond,
+			}
+			if c.invMass1+c.invMass2 == 0.0 {
+				continue
+			}
+			constraints = append(constraints, c)
+		}
+	}
+
+	// Solve (forward and backward passes converge faster for chains, Particle IDs keep the painting order)
+	sort.Slice(constraints, func(i, j int) bool {
+		return constraints[i].tile1.Particle.ID() < constraints[j].tile1.Particle.ID()
+	})
+	for i := 0; i < bondSolverIterations; i++ {
+		for j := range constraints {
+			c := constraints[j]
+			if i%2 == 1 {
+				c = constraints[len(constraints)-1-j]
+			}
+
+			x1, y1 := bondPredictedPosition(c.tile1)
+			x2, y2 := bondPredictedPosition(c.tile2)
+
+			dx, dy := x2-x1, y2-y1
+			length := math.Hypot(dx, dy)
+			lengthErr := length - c.bond.RestLength
+			if length < 1e-6 || (c.bond.Type == types.BondTypeRope && lengthErr <= 0.0) {
+				continue
+			}
+
+			stiffness := bondRopeStiffness
+			if c.bond.Type == types.BondTypeSpring {
+				stiffness = bondSpringStiffness
+			}
+
+			k := stiffness * lengthErr / length / (c.invMass1 + c.invMass2)
+			if c.invMass1 > 0.0 {
+				corrK := k * c.invMass1
+				c.tile1.Particle.AddForce(pkg.NewVectorByCoordinates(math.Abs(corrK)*length, 0, 0, corrK*dx, corrK*dy))
+			}
+			if c.invMass2 > 0.0 {
+				corrK := -k * c.invMass2
+				c.tile2.Particle.AddForce(pkg.NewVectorByCoordinates(math.Abs(corrK)*length, 0, 0, corrK*dx, corrK*dy))
+			}
+		}
+	}
+}
+
+// createStrokeBonds bonds new Particles painted with a bond tool to the ones painted before within the same stroke.
+// Particles are bonded with their stroke neighbours (forming a mesh for a circle tool), a fast cursor move gap
+// (no neighbours painted before) is bridged by a single bond.
+// The very first stroke Particles are also anchored to the unmovable / rigid body neighbours.
+// Bonds are not created here, BondCreate Actions are queued to be applied within the next processing round.
+func (m *Map) createStrokeBonds(strokeID int, bondType types.BondType, newTiles []*types.Tile) {
+	if strokeID != m.bondStrokeID || m.bondStrokeParticleIDs == nil {
+		m.bondStrokeID = strokeID
+		m.bondStrokeParticleIDs = make(map[uint64]bool)
+		m.bondStrokeLastPID = 0
+	}
+	if len(newTiles) == 0 {
+		return
+	}
+
+	isStrokeStart := len(m.bondStrokeParticleIDs) == 0
+	isNewParticle := make(map[uint64]bool, len(newTiles))
+	for _, tile := range newTiles {
+		m.bondStrokeParticleIDs[tile.Particle.ID()] = true
+		isNewParticle[tile.Particle.ID()] = true
+	}
+
+	// Bonds queued within this call by the Particle IDs pair (lower ID first)
+	pendingBonds := make(map[[2]uint64]bool)
+	pairKey := func(tile1, tile2 *types.Tile) [2]uint64 {
+		pid1, pid2 := tile1.Particle.ID(), tile2.Particle.ID()
+		if pid1 > pid2 {
+			pid1, pid2 = pid2, pid1
+		}
+		return [2]uint64{pid1, pid2}
+	}
+	isBonded := func(tile1, tile2 *types.Tile) bool {
+		if _, found := tile1.Particle.Bonds()[tile2.Particle.ID()]; found {
+			return true
+		}
+		return pendingBonds[pairKey(tile1, tile2)]
+	}
+	bondTiles := func(tile1, tile2 *types.Tile) {
+		pendingBonds[pairKey(tile1, tile2)] = true
+		m.inputTileActions = append(m.inputTileActions, types.NewBondCreate(tile1.Pos, tile1.Particle.ID(), tile2.Pos, tile2.Particle.ID(), types.ParticleBond{
+			Type:       bondType,
+			RestLength: math.Hypot(float64(tile2.Pos.X-tile1.Pos.X), float64(tile2.Pos.Y-tile1.Pos.Y)),
+		}))
+	}
+
+	isGapBridged := isStrokeStart
+	for _, tile := range newTiles {
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				x, y := tile.Pos.X+dx, tile.Pos.Y+dy
+				if (dx == 0 && dy == 0) || !m.isPositionValid(x, y) {
+					continue
+				}
+
+				neighbourTile := m.getTile(x, y)
+				if !neighbourTile.HasParticle() {
+					continue
+				}
+				neighbourParticle := neighbourTile.Particle
+
+				if m.bondStrokeParticleIDs[neighbourParticle.ID()] {
+					if isBonded(tile, neighbourTile) {
+						continue
+					}
+					bondTiles(tile, neighbourTile)
+					if !isNewParticle[neighbourParticle.ID()] {
+						isGapBridged = true
+					}
+					continue
+				}
+
+				neighbourMaterial := neighbourParticle.Material()
+				isAnchor := neighbourParticle.BodyID() != 0 || neighbourMaterial.IsFlagged(types.MaterialFlagIsUnmovable)
+				if isStrokeStart && isAnchor && neighbourMaterial.Type() != types.MaterialTypeBorder {
+					bondTiles(tile, neighbourTile)
+				}
+			}
+		}
+	}
+
+	// Bridge the gap to the previous stroke Particle with the closest new one
+	if lastTile, found := m.particles[m.bondStrokeLastPID]; found && !isGapBridged {
+		closestTile, closestDist := newTiles[0], math.MaxFloat64
+		for _, tile := range newTiles {
+			dist := math.Hypot(float64(tile.Pos.X-lastTile.Pos.X), float64(tile.Pos.Y-lastTile.Pos.Y))
+			if dist < closestDist {
+				closestTile, closestDist = tile, dist
+			}
+		}
+		bondTiles(closestTile, lastTile)
+	}
+	m.bondStrokeLastPID = newTiles[len(newTiles)-1].Particle.ID()
+}
+
+// bondPredictedPosition returns the Particle position predicted for the next processing round.
+func bondPredictedPosition(tile *types.Tile) (float64, float64) {
+	offsetX, offsetY := tile.Particle.Offset()
+	forceVec := tile.Particle.ForceVector()
+
+	return float64(tile.Pos.X) + offsetX + forceVec.X(), float64(tile.Pos.Y) + offsetY + forceVec.Y()
+}
+
+// bondInvMass returns the bonded Particle inverse mass (unmovable Particles are not moved by bonds).
+// A rigid body member is moved by the body, so the force is converted to a body impulse.
+func bondInvMass(particle *types.Particle) float64 {
+	material := particle.Material()
+	if particle.BodyID() == 0 && material.IsFlagged(types.MaterialFlagIsUnmovable) {
+		return 0.0
+	}
+
+	return 1.0 / material.Mass()
+}
@@ -10,6 +10,16 @@ func (e *Environment) ReplaceSelf(newMaterial types.Material) bool {
 	return true
 }
 
+func (e *Environment) ReplaceSelfWithState(newMaterial types.Material, state types.ParticleState) bool {
+	e.actions = append(e.actions, types.NewTileReplaceWithState(e.source.Pos, e.source.Particle.ID(), newMaterial, state))
+	return true
+}
+
+func (e *Environment) Explode(radius int, impulse, heat, destructionHealth float64) bool {
+	e.actions = append(e.actions, types.NewExplode(e.source.Pos, e.source.Particle.ID(), radius, impulse, heat, destructionHealth))
+	return true
+}
+
 func (e *Environment) UpdateStateParam(paramKey string, paramValue int) bool {
 	if e.source.Particle.GetStateParam(paramKey) == paramValue {
 		return false
@@ -28,3 +38,8 @@ func (e *Environment) SetSelfForce(vec pkg.Vector) bool {
 	e.actions = append(e.actions, types.NewAlterForce(e.source.Pos, e.source.Particle.ID(), vec))
 	return true
 }
+
+func (e *Environment) DepositPheromone(pType types.PheromoneType, amount float64) bool {
+	e.actions = append(e.actions, types.NewDepositPheromone(e.source.Pos, e.source.Particle.ID(), pType, amount))
+	return true
+}
@@ -9,6 +9,10 @@ import (
 )
 
 func (e *Environment) AddNewNeighbourTile(newMaterial types.Material, dirFilters []pkg.Direction) bool {
+	return e.AddNewNeighbourTileWithState(newMaterial, dirFilters, nil)
+}
+
+func (e *Environment) AddNewNeighbourTileWithState(newMaterial types.Material, dirFilters []pkg.Direction, state types.ParticleState) bool {
 	var dirs []pkg.Direction
 	if len(dirFilters) > 0 {
 		dirs = dirFilters
@@ -29,7 +33,7 @@ func (e *Environment) AddNewNeighbourTile(newMaterial types.Material, dirFilters
 	if len(tileCandidates) == 0 {
 		return false
 	}
-	e.actions = append(e.actions, types.NewTileAdd(tileCandidates[0].Pos, newMaterial))
+	e.actions = append(e.actions, types.NewTileAddWithState(tileCandidates[0].Pos, newMaterial, state))
 
 	return true
 }
@@ -52,6 +56,38 @@ func (e *Environment) ReplaceNeighbourTile(newMaterial types.Material, flagFilte
 	return true
 }
 
+func (e *Environment) ReplaceNeighbourTileByType(newMaterial types.Material, typeFilters []types.MaterialType) bool {
+	return e.ReplaceNeighbourTileByTypeWithState(newMaterial, typeFilters, nil)
+}
+
+func (e *Environment) ReplaceNeighbourTileByTypeWithState(newMaterial types.Material, typeFilters []types.MaterialType, state types.ParticleState) bool {
+	tileCandidates, _ := e.getNeighbours(
+		pkg.ValuePtr(false),
+		pkg.AllDirections, true,
+		typeFilters, true,
+		nil, false,
+	)
+	if len(tileCandidates) == 0 {
+		return false
+	}
+
+	replacementTile := tileCandidates[rand.Intn(len(tileCandidates))]
+	e.removeHealthReductions(replacementTile.Pos)
+	e.actions = append(e.actions, types.NewTileReplaceWithState(replacementTile.Pos, replacementTile.Particle.ID(), newMaterial, state))
+
+	return true
+}
+
+func (e *Environment) UpdateNeighbourStateParam(dir pkg.Direction, paramKey string, paramValue int) bool {
+	tile := e.neighbours[dir]
+	if tile == nil || !tile.HasParticle() || tile.Particle.GetStateParam(paramKey) == paramValue {
+		return false
+	}
+
+	e.actions = append(e.actions, types.NewUpdateStateParam(tile.Pos, tile.Particle.ID(), paramKey, paramValue))
+	return true
+}
+
 func (e *Environment) AddNewNeighbourTileGrassStyle(newMaterial types.Material) bool {
 	var dirs []pkg.Direction
 	for dir, tile := range e.neighbours {
@@ -38,6 +38,10 @@ func (e *Environment) AddForceInRange(mag float64, notFlagFilters []types.Materi
 }
 
 func (e *Environment) AddNewTileInRange(newMaterial types.Material) bool {
+	return e.AddNewTileInRangeWithState(newMaterial, nil)
+}
+
+func (e *Environment) AddNewTileInRangeWithState(newMaterial types.Material, state types.ParticleState) bool {
 	tileCandidates, _ := e.getTilesInRange(
 		pkg.ValuePtr(true), nil,
 		nil, false,
@@ -48,7 +52,24 @@ func (e *Environment) AddNewTileInRange(newMaterial types.Material) bool {
 	}
 
 	tileCandidate := tileCandidates[rand.Intn(len(tileCandidates))]
-	e.actions = append(e.actions, types.NewTileAdd(tileCandidate.Pos, newMaterial))
+	e.actions = append(e.actions, types.NewTileAddWithState(tileCandidate.Pos, newMaterial, state))
+
+	return true
+}
+
+func (e *Environment) ConsumeTileInRange(distance float64, typeFilters []types.MaterialType, absorbK float64) bool {
+	tileCandidates, _ := e.getTilesInRange(
+		pkg.ValuePtr(false),
+		pkg.ValuePtr(distance),
+		typeFilters, true,
+		nil, false,
+	)
+	if len(tileCandidates) == 0 {
+		return false
+	}
+
+	preyTile := tileCandidates[rand.Intn(len(tileCandidates))]
+	e.actions = append(e.actions, types.NewConsume(e.source.Pos, e.source.Particle.ID(), preyTile.Pos, preyTile.Particle.ID(), absorbK))
 
 	return true
 }
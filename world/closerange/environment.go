@@ -7,15 +7,28 @@ import (
 
 // Environment defines the state for a Particle self-processing (altering itself and surrounding neighbours based on Material logic).
 type Environment struct {
-	source       *types.Tile                   // source Tile
-	sourceHealth float64                       // source health (the current value since output Actions can rely on it)
-	neighbours   map[pkg.Direction]*types.Tile // neighbour tiles by a relative to source direction
+	source        *types.Tile                   // source Tile
+	sourceHealth  float64                       // source health (the current value since output Actions can rely on it)
+	sourceTemp    float64                       // source Tile temperature
+	sourcePress   float64                       // source Tile pressure
+	sourceWind    pkg.Vector                    // source Tile wind
+	sourceGravity pkg.Vector                    // source Tile gravity
+	sourceLight   float64                       // source Tile light level
+	pheromoneFn   PheromoneReader               // pheromone fields reader
+	pathFn        PathFinder                    // long-range pathfinding service
+	neighbours    map[pkg.Direction]*types.Tile // neighbour tiles by a relative to source direction
 	//
 	tilesInRange []*types.Tile // tiles in a circle range
 	//
 	actions []types.Action // processing output
 }
 
+// PheromoneReader defines a pheromone field getter (returns the level at the Position).
+type PheromoneReader func(pType types.PheromoneType, x, y int) float64
+
+// PathFinder defines a pathfinding query (returns the next step Direction towards the nearest target Material Tile).
+type PathFinder func(from types.Position, targetType types.MaterialType, mode types.PathMode) (pkg.Direction, int, bool)
+
 // NewEnvironment creates a new empty Environment.
 func NewEnvironment(sourceTile *types.Tile) *Environment {
 	env := &Environment{
@@ -52,6 +65,41 @@ func (e *Environment) SetNeighbour(dir pkg.Direction, tile *types.Tile) {
 	e.neighbours[dir] = tile
 }
 
+// SetTemperature sets the source Tile temperature.
+func (e *Environment) SetTemperature(temperature float64) {
+	e.sourceTemp = temperature
+}
+
+// SetPressure sets the source Tile pressure.
+func (e *Environment) SetPressure(pressure float64) {
+	e.sourcePress = pressure
+}
+
+// SetWind sets the source Tile wind Vector.
+func (e *Environment) SetWind(windVec pkg.Vector) {
+	e.sourceWind = windVec
+}
+
+// SetGravity sets the source Tile gravity Vector.
+func (e *Environment) SetGravity(gravityVec pkg.Vector) {
+	e.sourceGravity = gravityVec
+}
+
+// SetLight sets the source Tile light level.
+func (e *Environment) SetLight(light float64) {
+	e.sourceLight = light
+}
+
+// SetPheromoneReader sets the pheromone fields reader (the fields are read-only while Tiles are processed).
+func (e *Environment) SetPheromoneReader(fn PheromoneReader) {
+	e.pheromoneFn = fn
+}
+
+// SetPathFinder sets the pathfinding service.
+func (e *Environment) SetPathFinder(fn PathFinder) {
+	e.pathFn = fn
+}
+
 // AddTileInRange adds a neighbour in a circle range.
 func (e *Environment) AddTileInRange(tile *types.Tile) {
 	e.tilesInRange = append(e.tilesInRange, tile)
@@ -72,6 +120,40 @@ func (e *Environment) ForceVec() pkg.Vector {
 	return e.source.Particle.ForceVector()
 }
 
+// Temperature returns the source Tile temperature.
+func (e *Environment) Temperature() float64 {
+	return e.sourceTemp
+}
+
+// Pressure returns the source Tile pressure.
+func (e *Environment) Pressure() float64 {
+	return e.sourcePress
+}
+
+// Light returns the source Tile light level.
+func (e *Environment) Light() float64 {
+	return e.sourceLight
+}
+
+// Pheromone returns the pheromone level at the source Tile or its neighbour.
+func (e *Environment) Pheromone(pType types.PheromoneType, dir pkg.Direction) float64 {
+	if e.pheromoneFn == nil {
+		return 0.0
+	}
+
+	dx, dy := dir.Offset()
+	return e.pheromoneFn(pType, e.source.Pos.X+dx, e.source.Pos.Y+dy)
+}
+
+// FindPath returns the next step Direction towards the nearest reachable {targetType} Material Tile.
+func (e *Environment) FindPath(targetType types.MaterialType, mode types.PathMode) (pkg.Direction, int, bool) {
+	if e.pathFn == nil {
+		return pkg.DirectionNone, 0, false
+	}
+
+	return e.pathFn(e.source.Pos, targetType, mode)
+}
+
 // StateParam returns the source Particle internal state param.
 func (e *Environment) StateParam(key string) int {
 	return e.source.Particle.GetStateParam(key)
@@ -82,6 +164,15 @@ func (e *Environment) Actions() []types.Action {
 	return e.actions
 }
 
+// gravityDirection returns the source Tile gravity Direction (DirectionNone if there is no gravity).
+func (e *Environment) gravityDirection() pkg.Direction {
+	if e.sourceGravity.IsZero() {
+		return pkg.DirectionNone
+	}
+
+	return pkg.NewDirectionFromAngle(e.sourceGravity.Angle())
+}
+
 // getNeighbours returns neighbour tiles matching criteria with corresponding direction (relative to the source).
 func (e *Environment) getNeighbours(isEmpty *bool, dirs []pkg.Direction, dirsIn bool, mTypes []types.MaterialType, mTypesIn bool, mFlags []types.MaterialFlag, mFlagsIn bool) ([]*types.Tile, []pkg.Direction) {
 	var tiles []*types.Tile
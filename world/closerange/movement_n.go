@@ -7,10 +7,80 @@ import (
 	"github.com/itiky/goPixelWorld/world/types"
 )
 
+const (
+	// liquidPressureMoveThreshold defines the min liquid pressure to move up / sideways.
+	liquidPressureMoveThreshold = 2.0
+	// gasPressureMoveThreshold defines the min gas pressure (the number of gas neighbours) to expand.
+	gasPressureMoveThreshold = 3.0
+)
+
+// MoveTileWithPressure moves the pressurized liquid / gas Particle to an empty neighbour Tile.
+// Liquid rises against the local gravity (or moves sideways) if its pressure is above the threshold,
+// gas expands to any direction if it is surrounded by other gas Particles.
+func (e *Environment) MoveTileWithPressure() bool {
+	material := e.source.Particle.Material()
+
+	flow := material.Flow()
+	if !pkg.RollChance(flow.DispersionRate * (1.0 - flow.Viscosity)) {
+		return false
+	}
+
+	// "Up" is against the local gravity
+	upDir := e.gravityDirection().Rotate180()
+
+	var dirs []pkg.Direction
+	switch {
+	case material.IsFlagged(types.MaterialFlagIsLiquid):
+		if e.sourcePress < liquidPressureMoveThreshold || upDir == pkg.DirectionNone {
+			return false
+		}
+		dirs = []pkg.Direction{upDir, upDir.Rotate(-2), upDir.Rotate(2)}
+	case material.IsFlagged(types.MaterialFlagIsGas):
+		if e.sourcePress < gasPressureMoveThreshold {
+			return false
+		}
+		dirs = pkg.AllDirections
+	default:
+		return false
+	}
+
+	tileCandidates, tilesDir := e.getNeighbours(
+		pkg.ValuePtr(true),
+		dirs, true,
+		nil, false,
+		nil, false,
+	)
+	if len(tileCandidates) == 0 {
+		return false
+	}
+
+	// Pressurized liquid prefers to rise (communicating vessels)
+	targetTile := tileCandidates[rand.Intn(len(tileCandidates))]
+	for i, dir := range tilesDir {
+		if dir == upDir && material.IsFlagged(types.MaterialFlagIsLiquid) {
+			targetTile = tileCandidates[i]
+			break
+		}
+	}
+	// Pressure counteracts the gravity (the moved liquid shouldn't fall back right away)
+	if material.IsFlagged(types.MaterialFlagIsLiquid) {
+		e.actions = append(e.actions, types.NewAlterForce(e.source.Pos, e.source.Particle.ID(), pkg.NewVector(0, 0)))
+	}
+	e.actions = append(e.actions, types.NewMoveTile(e.source.Pos, e.source.Particle.ID(), targetTile.Pos))
+
+	return true
+}
+
 func (e *Environment) MoveTileWithNeighboursGasStyle() bool {
+	// Gas rises against the local gravity
+	upDir := e.gravityDirection().Rotate180()
+	if upDir == pkg.DirectionNone {
+		upDir = pkg.DirectionTop
+	}
+
 	tileCandidates, tilesDir := e.getNeighbours(
 		pkg.ValuePtr(true),
-		pkg.DirectionTop.Sector(1), true,
+		upDir.Sector(1), true,
 		nil, false,
 		nil, false,
 	)
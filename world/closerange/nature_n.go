@@ -0,0 +1,37 @@
+package closerange
+
+import (
+	"math"
+
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+func (e *Environment) AddBuoyancy() bool {
+	gravityVec := e.sourceGravity
+	if gravityVec.IsZero() {
+		return false
+	}
+
+	// Surrounding liquid density and the submerged share
+	liquidCnt, liquidMass := 0, 0.0
+	for _, tile := range e.neighbours {
+		if tile == nil || !tile.HasParticle() {
+			continue
+		}
+		if material := tile.Particle.Material(); material.IsFlagged(types.MaterialFlagIsLiquid) {
+			liquidCnt++
+			liquidMass += material.Mass()
+		}
+	}
+	sourceMass := e.source.Particle.Material().Mass()
+	if liquidCnt == 0 || sourceMass <= 0.0 {
+		return false
+	}
+
+	densityRatio := (liquidMass / float64(liquidCnt)) / sourceMass
+	submergedShare := float64(liquidCnt) / 8.0
+	buoyancyVec := gravityVec.Rotate(math.Pi).MultiplyByK(densityRatio * submergedShare)
+
+	e.actions = append(e.actions, types.NewAddForce(e.source.Pos, e.source.Particle.ID(), buoyancyVec))
+	return true
+}
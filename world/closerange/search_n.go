@@ -19,3 +19,20 @@ func (e *Environment) SearchNeighbours(
 		flagFilters, flagIn,
 	)
 }
+
+func (e *Environment) IsSurroundedByLiquid() bool {
+	hasLiquid := false
+	for _, tile := range e.neighbours {
+		if tile == nil {
+			continue
+		}
+		if !tile.HasParticle() {
+			return false
+		}
+		if tile.Particle.Material().IsFlagged(types.MaterialFlagIsLiquid) {
+			hasLiquid = true
+		}
+	}
+
+	return hasLiquid
+}
@@ -15,6 +15,10 @@ type Environment struct {
 	source     *types.Tile                   // source Particle (the one who wants to move to the target's Position)
 	target     *types.Tile                   // target Particle
 	neighbours map[pkg.Direction]*types.Tile // neighbour Particles by a relative to source -> target direction
+	gravityDir pkg.Direction                 // local gravity direction at the source Position (DirectionNone if there is no gravity)
+	// Granular source state
+	isSourceWet bool                  // is source Particle next to a liquid
+	dropDepths  map[pkg.Direction]int // number of empty Tiles in the collision direction starting from the neighbour (by direction)
 	//
 	actions []types.Action // output Actions
 }
@@ -26,6 +30,7 @@ func NewEnvironment(direction pkg.Direction, sourceTile, targetTile *types.Tile)
 		source:     sourceTile,
 		target:     targetTile,
 		neighbours: make(map[pkg.Direction]*types.Tile, 5),
+		dropDepths: make(map[pkg.Direction]int, 2),
 	}
 
 	return &e
@@ -42,6 +47,12 @@ func (e *Environment) Reset(direction pkg.Direction, sourceTile, targetTile *typ
 	for dir := range e.neighbours {
 		e.neighbours[dir] = nil
 	}
+
+	e.gravityDir = pkg.DirectionNone
+	e.isSourceWet = false
+	for dir := range e.dropDepths {
+		e.dropDepths[dir] = 0
+	}
 }
 
 // TargetMaterial returns the target Particle Material.
@@ -57,6 +68,22 @@ func (e *Environment) SetNeighbour(dir pkg.Direction, tile *types.Tile) {
 	e.neighbours[dir] = tile
 }
 
+// SetGravityDirection sets the local gravity direction.
+func (e *Environment) SetGravityDirection(dir pkg.Direction) {
+	e.gravityDir = dir
+}
+
+// SetSourceWet sets the granular source Particle wetness.
+func (e *Environment) SetSourceWet(isWet bool) {
+	e.isSourceWet = isWet
+}
+
+// SetNeighbourDropDepth sets the number of empty Tiles in the collision direction starting from the target neighbor.
+// Used by a granular source Particle to check the angle of repose.
+func (e *Environment) SetNeighbourDropDepth(dir pkg.Direction, depth int) {
+	e.dropDepths[dir] = depth
+}
+
 // IsFlagged checks if the source Particle has MaterialFlag.
 func (e *Environment) IsFlagged(flag types.MaterialFlag) bool {
 	return e.source.Particle.Material().IsFlagged(flag)
@@ -1,23 +1,51 @@
 package collision
 
 import (
+	"math"
+
 	"github.com/itiky/goPixelWorld/pkg"
 	"github.com/itiky/goPixelWorld/world/types"
 )
 
 // MoveSandSource moves the source Particle for sand-like Materials.
+// Left / right slide order is random, the slide is possible if the drop is steeper than the source angle of repose
+// and the source cohesion doesn't hold it (wet Particle uses wet properties).
+// For a falling source, target neighbours are relative to the local gravity direction (not the collision one).
 // Criteria:
-//   - if target's left neighbour is empty, pick it;
-//   - if target's right neighbour is empty, pick it;
+//   - if target's left / right neighbour is empty and the slope is steep enough, pick it;
 //   - if target's top neighbour is empty, pick it (stack);
 func (e *Environment) MoveSandSource() bool {
-	if neighbourTile := e.getEmptyNeighbour(pkg.DirectionLeft); neighbourTile != nil {
-		e.actions = append(e.actions, types.NewMoveTile(e.source.Pos, e.source.Particle.ID(), neighbourTile.Pos))
-		return true
+	granular := e.source.Particle.Material().Granular()
+	reposeAngle, cohesion := granular.ReposeAngle, granular.Cohesion
+	if e.isSourceWet {
+		reposeAngle, cohesion = granular.WetReposeAngle, granular.WetCohesion
 	}
-	if neighbourTile := e.getEmptyNeighbour(pkg.DirectionRight); neighbourTile != nil {
-		e.actions = append(e.actions, types.NewMoveTile(e.source.Pos, e.source.Particle.ID(), neighbourTile.Pos))
-		return true
+	minDropDepth := math.Tan(math.Min(reposeAngle, pkg.DegToRadAngle(89.0)))
+
+	slideDirs := [2]pkg.Direction{pkg.DirectionLeft, pkg.DirectionRight}
+	if pkg.FlipCoin() {
+		slideDirs[0], slideDirs[1] = slideDirs[1], slideDirs[0]
+	}
+
+	if pkg.RollChance(1.0 - cohesion) {
+		for _, dir := range slideDirs {
+			neighbourTile := e.getEmptyNeighbour(dir)
+			if neighbourTile == nil {
+				continue
+			}
+
+			// Neighbour is empty, so the drop is at least one Tile deep
+			dropDepth := e.dropDepths[dir]
+			if dropDepth < 1 {
+				dropDepth = 1
+			}
+			if float64(dropDepth) < minDropDepth {
+				continue
+			}
+
+			e.actions = append(e.actions, types.NewMoveTile(e.source.Pos, e.source.Particle.ID(), neighbourTile.Pos))
+			return true
+		}
 	}
 	if neighbourTile := e.getEmptyNeighbour(pkg.DirectionTop); neighbourTile != nil {
 		e.actions = append(e.actions, types.NewMoveTile(e.source.Pos, e.source.Particle.ID(), neighbourTile.Pos))
@@ -28,6 +56,8 @@ func (e *Environment) MoveSandSource() bool {
 }
 
 // MoveLiquidSource moves the source Particle for liquid-like Materials.
+// Viscous Materials spread with the (1.0 - viscosity) chance, otherwise they are stacked.
+// For a falling source, target neighbours are relative to the local gravity direction (not the collision one).
 // Criteria:
 //   - if target's left neighbour is empty, pick it;
 //   - if target's right neighbour is empty, pick it;
@@ -35,6 +65,14 @@ func (e *Environment) MoveSandSource() bool {
 //   - if target's top-right neighbour is empty, pick it (spread);
 //   - if target's top neighbour is empty, pick it (stack);
 func (e *Environment) MoveLiquidSource() bool {
+	if pkg.RollChance(e.source.Particle.Material().Flow().Viscosity) {
+		if neighbourTile := e.getEmptyNeighbour(pkg.DirectionTop); neighbourTile != nil {
+			e.actions = append(e.actions, types.NewMoveTile(e.source.Pos, e.source.Particle.ID(), neighbourTile.Pos))
+			return true
+		}
+		return false
+	}
+
 	if neighbourTile := e.getEmptyNeighbour(pkg.DirectionLeft); neighbourTile != nil {
 		e.actions = append(e.actions, types.NewMoveTile(e.source.Pos, e.source.Particle.ID(), neighbourTile.Pos))
 		return true
@@ -62,6 +100,65 @@ func (e *Environment) MoveLiquidSource() bool {
 	return false
 }
 
+// DisplaceByDensity swaps the source and target Particles if one sinks / floats through the other one.
+// Material mass is used as a density:
+//   - a heavier movable source falling down to a lighter liquid / gas target sinks through it;
+//   - a lighter liquid / gas source moving up to a heavier liquid / gas target floats up through it;
+//   - a lighter movable source moving up to a heavier liquid target floats up through it (buoyancy);
+//
+// "Down" and "up" are relative to the local gravity direction.
+//
+// Displacement chance depends on the density ratio and the target viscosity.
+func (e *Environment) DisplaceByDensity() bool {
+	isFluid := func(m types.Material) bool {
+		return m.IsFlagged(types.MaterialFlagIsLiquid) || m.IsFlagged(types.MaterialFlagIsGas)
+	}
+	isDirectionIn := func(dirs []pkg.Direction) bool {
+		for _, dir := range dirs {
+			if dir == e.direction {
+				return true
+			}
+		}
+		return false
+	}
+
+	sourceMaterial, targetMaterial := e.source.Particle.Material(), e.target.Particle.Material()
+	if !isFluid(targetMaterial) || sourceMaterial.IsFlagged(types.MaterialFlagIsUnmovable) || targetMaterial.IsFlagged(types.MaterialFlagIsUnmovable) {
+		return false
+	}
+
+	var densityRatio float64
+	sourceMass, targetMass := sourceMaterial.Mass(), targetMaterial.Mass()
+	switch {
+	case sourceMass > targetMass && isDirectionIn(e.gravityDir.Sector(1)):
+		densityRatio = targetMass / sourceMass
+	case sourceMass < targetMass && (isFluid(sourceMaterial) || targetMaterial.IsFlagged(types.MaterialFlagIsLiquid)) && isDirectionIn(e.gravityDir.Rotate180().Sector(1)):
+		densityRatio = sourceMass / targetMass
+	default:
+		return false
+	}
+
+	if !pkg.RollChance((1.0 - densityRatio) * (1.0 - targetMaterial.Flow().Viscosity)) {
+		return false
+	}
+
+	return e.SwapSourceTarget()
+}
+
+// SwimThrough swaps the source and target Particles if the swimmer source moves through a liquid target.
+// Swimmers move freely in any direction within liquids (the target viscosity slows them down).
+func (e *Environment) SwimThrough() bool {
+	sourceMaterial, targetMaterial := e.source.Particle.Material(), e.target.Particle.Material()
+	if !sourceMaterial.IsFlagged(types.MaterialFlagIsSwimmer) || !targetMaterial.IsFlagged(types.MaterialFlagIsLiquid) {
+		return false
+	}
+	if !pkg.RollChance(1.0 - targetMaterial.Flow().Viscosity) {
+		return false
+	}
+
+	return e.SwapSourceTarget()
+}
+
 // SwapSourceTarget swaps the source and target Particles.
 func (e *Environment) SwapSourceTarget() bool {
 	e.actions = append(e.actions, types.NewSwapTiles(e.source.Pos, e.source.Particle.ID(), e.target.Pos, e.target.Particle.ID()))
@@ -6,31 +6,43 @@ import (
 )
 
 // ReflectSourceTargetForces reflects the source and the target Particle force Vectors based on collision angle and mass ratio.
+// If the target Material has the collision response configured for the source Material,
+// restitution and friction coefficients are used instead of the {sourceForceDampK} (the collision normal is the contact direction then).
 // https://www.vobarian.com/collisions/2dcollisions2.pdf
 func (e *Environment) ReflectSourceTargetForces(sourceForceDampK float64) bool {
 	sourceVecBefore, targetVecBefore := e.source.Particle.ForceVector(), e.target.Particle.ForceVector()
 	sourceMass, targetMass := e.source.Particle.Material().Mass(), e.target.Particle.Material().Mass()
 
-	if sourceForceDampK > 0.0 {
-		sourceVecBefore = sourceVecBefore.MultiplyByK(sourceForceDampK)
+	var normalVec pkg.Vector
+	pairProps, pairFound := e.target.Particle.Material().CollisionProps(e.source.Particle.Material().Type())
+	if pairFound {
+		// Contact normal points from the target to the source, friction damps the sliding (tangent) component
+		normalVec = pkg.NewVectorByCoordinates(1.0, float64(e.target.Pos.X), float64(e.target.Pos.Y), float64(e.source.Pos.X), float64(e.source.Pos.Y))
+	} else {
+		// Elastic collision with the source damping
+		pairProps.Restitution = 1.0
+		if sourceForceDampK > 0.0 {
+			sourceVecBefore = sourceVecBefore.MultiplyByK(sourceForceDampK)
+		}
+		normalVec = pkg.NewVectorByCoordinates(1.0, targetVecBefore.X(), targetVecBefore.Y(), sourceVecBefore.X(), sourceVecBefore.Y())
 	}
-
-	normalVec := pkg.NewVectorByCoordinates(1.0, targetVecBefore.X(), targetVecBefore.Y(), sourceVecBefore.X(), sourceVecBefore.Y())
 	tangentVec := normalVec.Rotate(pkg.Rad90)
 
 	sourceVecNormalPrBefore := normalVec.DotProduct(sourceVecBefore)
-	sourceVecTangentPrAfter := tangentVec.DotProduct(sourceVecBefore)
+	sourceVecTangentPrAfter := tangentVec.DotProduct(sourceVecBefore) * (1.0 - pairProps.Friction)
 	targetVecNormalPrBefore := normalVec.DotProduct(targetVecBefore)
 	targetVecTangentPrAfter := tangentVec.DotProduct(targetVecBefore)
 
-	sourceVecNormalPrAfter := (sourceVecNormalPrBefore*(sourceMass-targetMass) + 2.0*targetMass*targetVecNormalPrBefore) / (sourceMass + targetMass)
-	targetVecNormalPrAfter := (targetVecNormalPrBefore*(targetMass-sourceMass) + 2.0*sourceMass*sourceVecNormalPrBefore) / (sourceMass + targetMass)
+	massSum, momentum := sourceMass+targetMass, sourceMass*sourceVecNormalPrBefore+targetMass*targetVecNormalPrBefore
+	sourceVecNormalPrAfter := (momentum + targetMass*pairProps.Restitution*(targetVecNormalPrBefore-sourceVecNormalPrBefore)) / massSum
+	targetVecNormalPrAfter := (momentum + sourceMass*pairProps.Restitution*(sourceVecNormalPrBefore-targetVecNormalPrBefore)) / massSum
 
 	sourceVecAfter := normalVec.MultiplyByK(sourceVecNormalPrAfter).Add(tangentVec.MultiplyByK(sourceVecTangentPrAfter))
 	targetVecAfter := normalVec.MultiplyByK(targetVecNormalPrAfter).Add(tangentVec.MultiplyByK(targetVecTangentPrAfter))
 
 	e.actions = append(e.actions, types.NewAlterForce(e.source.Pos, e.source.Particle.ID(), sourceVecAfter))
-	if !e.target.Particle.Material().IsFlagged(types.MaterialFlagIsUnmovable) {
+	// Rigid body member force is converted to the body impulse
+	if !e.target.Particle.Material().IsFlagged(types.MaterialFlagIsUnmovable) || e.target.Particle.BodyID() != 0 {
 		e.actions = append(e.actions, types.NewAlterForce(e.target.Pos, e.target.Particle.ID(), targetVecAfter))
 	}
 
@@ -0,0 +1,119 @@
+package world
+
+import (
+	"math"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/materials"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+const (
+	// explosionParticleTransmission defines the blast share passing through a movable Particle.
+	explosionParticleTransmission = 0.6
+	// explosionObstacleDamageK defines the blast damage share applied to an unmovable Particle (walls are sturdy).
+	explosionObstacleDamageK = 0.2
+	// explosionReferenceMass defines the max Particle mass receiving the full blast impulse (heavier ones receive less).
+	explosionReferenceMass = 10.0
+	// explosionMinStrength defines the min blast strength [0.0, 1.0] that affects a Tile.
+	explosionMinStrength = 0.05
+	// explosionFireStrength defines the min blast strength to set an empty Tile on Fire (Smoke is spawned below).
+	explosionFireStrength = 0.5
+	// explosionSpawnChance defines the max chance of an empty Tile to be filled with the Fire / Smoke.
+	explosionSpawnChance = 0.3
+)
+
+// explode blasts a circle area around the exploding Tile replacing its Particle with the Fire.
+// Blast strength at a Position decreases with the distance to the center and is reduced by Particles on the way
+// (a ray from the center), an unmovable Particle blocks the blast completely (but takes the hit itself).
+// Explosive Particles are not damaged, they are heated up to make a chain reaction.
+func (m *Map) explode(sourceTile *types.Tile, params *types.Explode) {
+	if m.monitor != nil {
+		defer m.monitor.TrackOpDuration("Map.explode")()
+	}
+
+	center := sourceTile.Pos
+	if !m.removeParticle(sourceTile) {
+		return
+	}
+	m.createParticle(sourceTile, materials.NewFire())
+
+	for _, pos := range types.PositionsInCircle(center.X, center.Y, params.Radius, false) {
+		if !m.isPositionValid(pos.X, pos.Y) {
+			continue
+		}
+
+		strength := m.explosionStrength(center, pos, params.Radius)
+		if strength < explosionMinStrength {
+			continue
+		}
+
+		if temp := params.Heat * strength; m.heat.Get(pos.X, pos.Y) < temp {
+			m.heat.Set(pos.X, pos.Y, temp)
+		}
+
+		tile := m.getTile(pos.X, pos.Y)
+		if !tile.HasParticle() {
+			if pkg.RollChance(explosionSpawnChance * strength) {
+				if strength >= explosionFireStrength {
+					m.createParticle(tile, materials.NewFire())
+				} else {
+					m.createParticle(tile, materials.NewSmoke())
+				}
+			}
+			continue
+		}
+
+		material := tile.Particle.Material()
+		if material.IsFlagged(types.MaterialFlagIsUnremovable, types.MaterialFlagIsExplosive) {
+			continue
+		}
+		isObstacle := material.IsFlagged(types.MaterialFlagIsUnmovable) && tile.Particle.BodyID() == 0
+
+		// Damage / destroy
+		damage := params.DestructionHealth * strength
+		if isObstacle {
+			damage *= explosionObstacleDamageK
+		}
+		tile.Particle.ReduceHealth(damage)
+		if tile.Particle.IsDestroyed() {
+			m.removeParticle(tile)
+			if material.IsFlagged(types.MaterialFlagIsFlammable) {
+				m.createParticle(tile, materials.NewFire())
+			}
+			continue
+		}
+
+		// Push outward
+		if isObstacle {
+			continue
+		}
+		impulse := params.Impulse * strength * math.Min(1.0, explosionReferenceMass/material.Mass())
+		tile.Particle.AddForce(pkg.NewVectorByCoordinates(impulse, float64(center.X), float64(center.Y), float64(pos.X), float64(pos.Y)))
+	}
+}
+
+// explosionStrength returns the blast strength [0.0, 1.0] at the Position.
+// Strength decreases linearly with the distance and is reduced by each Particle on the way (excluding the target one).
+func (m *Map) explosionStrength(center, pos types.Position, radius int) float64 {
+	dist := math.Hypot(float64(pos.X-center.X), float64(pos.Y-center.Y))
+	strength := 1.0 - dist/float64(radius+1)
+	if strength <= 0.0 {
+		return 0.0
+	}
+
+	path := center.CreatePathTo(pos, m.width, m.height)
+	for i := 0; i < len(path)-1 && strength >= explosionMinStrength; i++ {
+		tile := m.getTile(path[i].X, path[i].Y)
+		if !tile.HasParticle() {
+			continue
+		}
+
+		if tile.Particle.Material().IsFlagged(types.MaterialFlagIsUnmovable) && tile.Particle.BodyID() == 0 {
+			return 0.0
+		}
+		strength *= explosionParticleTransmission
+	}
+
+	return strength
+}
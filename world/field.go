@@ -0,0 +1,53 @@
+package world
+
+// scalarField keeps a per-Tile scalar value state.
+// Field is double-buffered: the current state is read-only during the processing round and the next one is being filled up.
+type scalarField struct {
+	width, height int
+	cur, next     [][]float64
+}
+
+// newScalarField creates a new scalarField with all values set to {initial}.
+func newScalarField(width, height int, initial float64) *scalarField {
+	f := scalarField{
+		width:  width,
+		height: height,
+		cur:    make([][]float64, width),
+		next:   make([][]float64, width),
+	}
+	for x := 0; x < width; x++ {
+		f.cur[x] = make([]float64, height)
+		f.next[x] = make([]float64, height)
+		for y := 0; y < height; y++ {
+			f.cur[x][y] = initial
+			f.next[x][y] = initial
+		}
+	}
+
+	return &f
+}
+
+// Get returns the current value.
+func (f *scalarField) Get(x, y int) float64 {
+	return f.cur[x][y]
+}
+
+// Set sets the current value.
+func (f *scalarField) Set(x, y int, v float64) {
+	f.cur[x][y] = v
+}
+
+// SetNext sets the next round value.
+func (f *scalarField) SetNext(x, y int, v float64) {
+	f.next[x][y] = v
+}
+
+// Swap makes the next round state current.
+func (f *scalarField) Swap() {
+	f.cur, f.next = f.next, f.cur
+}
+
+// SwapValues swaps the current values between two Positions (a field value moves with a Particle).
+func (f *scalarField) SwapValues(x1, y1, x2, y2 int) {
+	f.cur[x1][y1], f.cur[x2][y2] = f.cur[x2][y2], f.cur[x1][y1]
+}
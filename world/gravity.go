@@ -0,0 +1,116 @@
+package world
+
+import (
+	"math"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+const (
+	// gravityMag defines the gravity Vector magnitude.
+	gravityMag = 0.15
+)
+
+// gravityField keeps the gravity state (directional or radial).
+type gravityField struct {
+	mode      types.GravityMode
+	angle     float64          // directional gravity angle (down by default)
+	centers   []types.Position // radial gravity centers
+	defCenter types.Position   // radial gravity center used if there are no centers defined (the grid center)
+	radialK   float64          // radial gravity sign (-1.0 if flipped: centers repel)
+}
+
+// newGravityField creates a new gravityField with the default (directional down) gravity.
+func newGravityField() gravityField {
+	return gravityField{
+		mode:    types.GravityModeDirectional,
+		angle:   pkg.Rad90,
+		radialK: 1.0,
+	}
+}
+
+// flip inverts the gravity (the directional one is rotated by 180 degrees, radial centers start to repel).
+func (g *gravityField) flip() {
+	g.angle = pkg.NormalizeAngle(g.angle + math.Pi)
+	g.radialK = -g.radialK
+}
+
+// setAngle sets the directional gravity angle.
+func (g *gravityField) setAngle(angleRad float64) {
+	g.angle = pkg.NormalizeAngle(angleRad)
+}
+
+// rotate rotates the directional gravity by the angle delta (clockwise for a positive one).
+func (g *gravityField) rotate(angleDeltaRad float64) {
+	g.setAngle(g.angle + angleDeltaRad)
+}
+
+// setCenters replaces the radial gravity centers.
+func (g *gravityField) setCenters(centers []types.Position) {
+	g.centers = append([]types.Position(nil), centers...)
+}
+
+// addCenter adds a new radial gravity center.
+func (g *gravityField) addCenter(center types.Position) {
+	g.centers = append(g.centers, center)
+}
+
+// at returns the gravity Vector at the Position.
+// Radial gravity points to the closest center (zero at the center itself).
+func (g *gravityField) at(x, y int) pkg.Vector {
+	if g.mode != types.GravityModeRadial {
+		return pkg.NewVector(gravityMag, g.angle)
+	}
+
+	center, dist := g.closestCenter(x, y)
+	if dist == 0.0 {
+		return pkg.NewVector(0, 0)
+	}
+
+	gravityVec := pkg.NewVectorByCoordinates(gravityMag, float64(x), float64(y), float64(center.X), float64(center.Y))
+	if g.radialK < 0.0 {
+		gravityVec = gravityVec.Rotate(math.Pi)
+	}
+
+	return gravityVec
+}
+
+// direction returns the gravity Direction at the Position (DirectionNone if there is no gravity).
+func (g *gravityField) direction(x, y int) pkg.Direction {
+	gravityVec := g.at(x, y)
+	if gravityVec.IsZero() {
+		return pkg.DirectionNone
+	}
+
+	return pkg.NewDirectionFromAngle(gravityVec.Angle())
+}
+
+// height returns the Position height against the gravity (the gravity potential).
+// The higher the value, the "upper" the Position is (for the default gravity that is -Y).
+func (g *gravityField) height(x, y int) float64 {
+	if g.mode != types.GravityModeRadial {
+		return -(float64(x)*math.Cos(g.angle) + float64(y)*math.Sin(g.angle))
+	}
+
+	_, dist := g.closestCenter(x, y)
+
+	return g.radialK * dist
+}
+
+// closestCenter returns the closest radial gravity center and the distance to it.
+func (g *gravityField) closestCenter(x, y int) (types.Position, float64) {
+	if len(g.centers) == 0 {
+		return g.defCenter, math.Hypot(float64(g.defCenter.X-x), float64(g.defCenter.Y-y))
+	}
+
+	var center types.Position
+	minDist := math.MaxFloat64
+	for _, c := range g.centers {
+		if dist := math.Hypot(float64(c.X-x), float64(c.Y-y)); dist < minDist {
+			center, minDist = c, dist
+		}
+	}
+
+	return center, minDist
+}
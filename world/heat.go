@@ -0,0 +1,122 @@
+package world
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/itiky/goPixelWorld/world/materials"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+const (
+	// heatAmbientTemperature defines the default (initial) temperature (the ambient one if there are no seasons).
+	heatAmbientTemperature = 20.0
+	// heatTransferRate defines the per round temperature equalization rate between neighbours.
+	heatTransferRate = 0.2
+	// heatAirConductivity defines the empty Tile heat conductivity.
+	heatAirConductivity = 0.05
+	// heatAirCapacity defines the empty Tile heat capacity.
+	heatAirCapacity = 1.0
+	// heatAirAmbientK defines the empty Tile temperature relaxation rate towards the ambient one (heat dissipation).
+	heatAirAmbientK = 0.01
+)
+
+// processHeat calculates the next temperature field state for the grid stripe [xFrom, xTo).
+// Each Tile temperature is equalized with its cross neighbours with respect to their conductivity and heat capacity.
+// Phase transitions (boiling, ignition, etc.) are pushed to the {output} queue as Actions.
+func (m *Map) processHeat(xFrom, xTo int, output *[]types.Action) {
+	thermalProps := func(tile *types.Tile) (conductivity, capacity float64) {
+		if !tile.HasParticle() {
+			return heatAirConductivity, heatAirCapacity
+		}
+
+		thermal := tile.Particle.Material().Thermal()
+		return thermal.Conductivity, thermal.HeatCapacity
+	}
+
+	for x := xFrom; x < xTo; x++ {
+		for y := 0; y < m.height; y++ {
+			tile := m.getTile(x, y)
+			temp := m.heat.Get(x, y)
+
+			conductivity, capacity := thermalProps(tile)
+			if conductivity == 0.0 {
+				m.heat.SetNext(x, y, temp)
+				continue
+			}
+
+			// Conduction
+			tempDelta := 0.0
+			equalize := func(dx, dy int) {
+				nx, ny := x+dx, y+dy
+				if !m.isPositionValid(nx, ny) {
+					return
+				}
+
+				nConductivity, _ := thermalProps(m.getTile(nx, ny))
+				tempDelta += math.Min(conductivity, nConductivity) * (m.heat.Get(nx, ny) - temp)
+			}
+			equalize(0, -1)
+			equalize(1, 0)
+			equalize(0, 1)
+			equalize(-1, 0)
+
+			if capacity <= 0.0 {
+				capacity = heatAirCapacity
+			}
+			temp += heatTransferRate * tempDelta / capacity
+
+			// Dissipation and heat sources
+			if !tile.HasParticle() {
+				temp += (m.ambientTemp - temp) * heatAirAmbientK
+				m.heat.SetNext(x, y, temp)
+				continue
+			}
+
+			thermal := tile.Particle.Material().Thermal()
+			if thermal.SourceTemperature > 0.0 && temp < thermal.SourceTemperature {
+				temp = thermal.SourceTemperature
+			}
+			m.heat.SetNext(x, y, temp)
+
+			// Phase transitions
+			for _, transition := range thermal.Transitions {
+				if !transition.IsTriggered(temp) {
+					continue
+				}
+
+				newMaterial := materials.AllMaterialsSet[transition.Material]
+				if newMaterial == nil {
+					continue
+				}
+				*output = append(*output, types.NewTileReplace(tile.Pos, tile.Particle.ID(), newMaterial))
+				break
+			}
+		}
+	}
+}
+
+// heatOverlayColor returns the temperature overlay color (red for hot, blue for cold Tiles).
+// Returns false if the temperature is close to the ambient one (no overlay required).
+func heatOverlayColor(temp float64) (color.NRGBA, bool) {
+	const (
+		tempThreshold = 5.0   // min deviation from the ambient temperature to render
+		tempRange     = 500.0 // deviation that gets the max alpha
+		alphaLevels   = 16    // alpha quantization levels (reduces the number of unique colors)
+	)
+
+	tempDiff := temp - heatAmbientTemperature
+	if math.Abs(tempDiff) < tempThreshold {
+		return color.NRGBA{}, false
+	}
+
+	level := math.Min(math.Abs(tempDiff)/tempRange, 1.0)
+	level = math.Ceil(level*alphaLevels) / alphaLevels
+
+	c := color.NRGBA{R: 0xFF, G: 0x20, B: 0x00, A: uint8(level * 0xC0)}
+	if tempDiff < 0.0 {
+		c = color.NRGBA{R: 0x00, G: 0x40, B: 0xFF, A: uint8(level * 0xC0)}
+	}
+
+	return c, true
+}
@@ -0,0 +1,68 @@
+package world
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+const (
+	// lightNightLevel defines the min daylight level (the night sky).
+	lightNightLevel = 0.1
+	// lightMinLevel defines the min light level to track (darker Tiles are considered unlit).
+	lightMinLevel = 0.01
+)
+
+// updateDaylight advances the day / night cycle and updates the current daylight level.
+// The cycle is enabled with nature effects only, otherwise it is always a day.
+func (m *Map) updateDaylight() {
+	dayLength := m.natureConfig.DayLength
+	if !m.natureEnabled || dayLength <= 0 {
+		m.daylight = 1.0
+		return
+	}
+
+	m.dayTime = (m.dayTime + 1) % dayLength
+	sun := math.Sin(2.0 * math.Pi * float64(m.dayTime) / float64(dayLength))
+	m.daylight = lightNightLevel + (1.0-lightNightLevel)*math.Max(0.0, sun)
+}
+
+// processLight calculates the next light field state for the grid stripe [xFrom, xTo).
+// Sunlight is cast down each column: an opaque Particle blocks it, a transparent one (Water, Smoke, etc.) dims it.
+// Grid borders are ignored (the sky is above them).
+// Tile light level is the light reaching it (before its own Particle has absorbed a part of it).
+func (m *Map) processLight(xFrom, xTo int) {
+	for x := xFrom; x < xTo; x++ {
+		light := m.daylight
+		for y := 0; y < m.height; y++ {
+			m.light.SetNext(x, y, light)
+			if light < lightMinLevel {
+				continue
+			}
+
+			if tile := m.getTile(x, y); tile.HasParticle() && tile.Particle.Material().Type() != types.MaterialTypeBorder {
+				light *= tile.Particle.Material().LightTransmission()
+				if light < lightMinLevel {
+					light = 0.0
+				}
+			}
+		}
+	}
+}
+
+// lightOverlayColor returns the light overlay color (darkness is shaded).
+// Returns false if the Tile is fully lit.
+func lightOverlayColor(light float64) (color.NRGBA, bool) {
+	const (
+		alphaLevels = 16 // alpha quantization levels (reduces the number of unique colors)
+	)
+
+	darkness := 1.0 - light
+	if darkness <= 0.0 {
+		return color.NRGBA{}, false
+	}
+	darkness = math.Ceil(darkness*alphaLevels) / alphaLevels
+
+	return color.NRGBA{R: 0x00, G: 0x00, B: 0x20, A: uint8(darkness * 0xD0)}, true
+}
@@ -0,0 +1,249 @@
+package world
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/materials"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+const (
+	// lightningTargetRange defines the max horizontal distance from the bolt start to its target [Tiles].
+	lightningTargetRange = 30
+	// lightningConductorK defines the conductive target distance multiplier (a lightning rod attracts the bolt).
+	lightningConductorK = 0.3
+	// lightningJitterChance defines the chance of a random horizontal bolt step (otherwise it steps towards the target).
+	lightningJitterChance = 0.4
+	// lightningBranchChance defines the chance of a new branch per bolt step.
+	lightningBranchChance = 0.08
+	// lightningBranchLenMax defines the max branch length [Tiles].
+	lightningBranchLenMax = 12
+	// lightningStrikeRadius defines the strike area radius (around the strike Position).
+	lightningStrikeRadius = 2
+	// lightningStrikeHeat defines the strike area center temperature.
+	lightningStrikeHeat = 1000.0
+	// lightningPathHeat defines the bolt path temperature.
+	lightningPathHeat = 300.0
+	// lightningConductedHeat defines the temperature a conductor Particle gets while conducting the strike.
+	lightningConductedHeat = 150.0
+	// lightningConductorMax defines the max number of conductor Particles the strike flows through.
+	lightningConductorMax = 1000
+)
+
+// lightningBolt defines a traced lightning bolt.
+type lightningBolt struct {
+	path      []types.Position   // main channel (from the top to the strike Position)
+	branches  [][]types.Position // side branches (fade in the air)
+	strikePos types.Position     // the strike Position (the last path one)
+	isStrike  bool               // false, if the bolt has faded in the air (nothing to hit)
+}
+
+// traceLightningBolt traces a branching bolt path from the top of the grid down to the nearest target.
+// Bolt shape is defined by the {rng} only (for the same Map state), so a seeded generator makes it deterministic.
+func (m *Map) traceLightningBolt(rng *rand.Rand, startX int) lightningBolt {
+	bolt := lightningBolt{}
+
+	startX = clampInt(startX, 1, m.width-2)
+	target, found := m.findLightningTarget(startX)
+	if !found {
+		target = types.Position{X: clampInt(startX+rng.Intn(2*lightningTargetRange+1)-lightningTargetRange, 1, m.width-2), Y: m.height - 2}
+	}
+
+	x, y := startX, 1
+	bolt.path = append(bolt.path, types.Position{X: x, Y: y})
+	for y < target.Y {
+		// Step towards the target (randomly, if there is enough room left)
+		dxToTarget, stepsLeft := target.X-x, target.Y-y
+		dx := pkg.CmpInt(0, dxToTarget)
+		if pkg.AbsInt(dxToTarget) < stepsLeft-1 && rng.Float64() < lightningJitterChance {
+			dx = rng.Intn(3) - 1
+		}
+		x, y = clampInt(x+dx, 1, m.width-2), y+1
+
+		pos := types.Position{X: x, Y: y}
+		bolt.path = append(bolt.path, pos)
+
+		// Hit something on the way
+		if tile := m.getTile(x, y); tile.HasParticle() && tile.Particle.Material().Type() != types.MaterialTypeBorder {
+			bolt.strikePos, bolt.isStrike = pos, true
+			return bolt
+		}
+
+		if rng.Float64() < lightningBranchChance {
+			bolt.branches = append(bolt.branches, m.traceLightningBranch(rng, pos))
+		}
+	}
+
+	return bolt
+}
+
+// traceLightningBranch traces a side branch (a random walk down) starting from the bolt Position.
+// Branch stops before any Particle.
+func (m *Map) traceLightningBranch(rng *rand.Rand, startPos types.Position) []types.Position {
+	dirX := 1
+	if rng.Intn(2) == 0 {
+		dirX = -1
+	}
+
+	var branch []types.Position
+	x, y := startPos.X, startPos.Y
+	for i := rng.Intn(lightningBranchLenMax) + 1; i > 0; i-- {
+		if rng.Intn(2) == 0 {
+			x += dirX
+		}
+		y++
+
+		if !m.isPositionValid(x, y) || m.getTile(x, y).HasParticle() {
+			break
+		}
+		branch = append(branch, types.Position{X: x, Y: y})
+	}
+
+	return branch
+}
+
+// findLightningTarget returns the bolt target: the nearest column top Particle within the range (tall ones are closer).
+// A conductive target distance is reduced, so a lightning rod is preferred.
+func (m *Map) findLightningTarget(startX int) (types.Position, bool) {
+	var target types.Position
+	found, targetDist := false, math.MaxFloat64
+	for x := startX - lightningTargetRange; x <= startX+lightningTargetRange; x++ {
+		if x <= 0 || x >= m.width-1 {
+			continue
+		}
+
+		for y := 1; y < m.height-1; y++ {
+			tile := m.getTile(x, y)
+			if !tile.HasParticle() {
+				continue
+			}
+
+			material := tile.Particle.Material()
+			if material.Type() == types.MaterialTypeBorder || material.IsFlagged(types.MaterialFlagIsGas) {
+				break
+			}
+
+			dist := math.Hypot(float64(x-startX), float64(y-1))
+			if material.IsFlagged(types.MaterialFlagIsConductive) {
+				dist *= lightningConductorK
+			}
+			if dist < targetDist {
+				target, targetDist, found = tile.Pos, dist, true
+			}
+			break
+		}
+	}
+
+	return target, found
+}
+
+// strikeLightning applies the bolt effects:
+//   - the bolt path is heated;
+//   - a conductor (Metal) conducts the strike to its lowest Particle (the strike area moves there);
+//   - flammable Particles within the strike area are set on Fire, Sand is fused into the Glass, the area is heated;
+func (m *Map) strikeLightning(bolt lightningBolt) {
+	heatUp := func(pos types.Position, temp float64) {
+		if m.isPositionValid(pos.X, pos.Y) && m.heat.Get(pos.X, pos.Y) < temp {
+			m.heat.Set(pos.X, pos.Y, temp)
+		}
+	}
+
+	for _, pos := range bolt.path {
+		heatUp(pos, lightningPathHeat)
+	}
+	m.lightningBolts = append(m.lightningBolts, bolt.path)
+	m.lightningBolts = append(m.lightningBolts, bolt.branches...)
+
+	if !bolt.isStrike {
+		return
+	}
+
+	strikePos := bolt.strikePos
+	if strikeTile := m.getTile(strikePos.X, strikePos.Y); strikeTile.Particle.Material().IsFlagged(types.MaterialFlagIsConductive) {
+		strikePos = m.conductLightning(strikeTile, heatUp)
+	}
+
+	for _, pos := range types.PositionsInCircle(strikePos.X, strikePos.Y, lightningStrikeRadius, true) {
+		if !m.isPositionValid(pos.X, pos.Y) {
+			continue
+		}
+
+		dist := math.Hypot(float64(pos.X-strikePos.X), float64(pos.Y-strikePos.Y))
+		heatUp(pos, lightningStrikeHeat*(1.0-dist/float64(lightningStrikeRadius+1)))
+
+		tile := m.getTile(pos.X, pos.Y)
+		if !tile.HasParticle() {
+			continue
+		}
+
+		material := tile.Particle.Material()
+		switch {
+		case material.Type() == types.MaterialTypeSand:
+			if m.removeParticle(tile) {
+				m.createParticle(tile, materials.NewGlass())
+			}
+		case material.IsFlagged(types.MaterialFlagIsFlammable) && !material.IsFlagged(types.MaterialFlagIsExplosive):
+			if m.removeParticle(tile) {
+				m.createParticle(tile, materials.NewFire())
+			}
+		}
+	}
+}
+
+// conductLightning spreads the strike through the connected conductor Particles heating them up.
+// Returns the discharge Position: the one below the lowest conductor Particle (the strike goes to the ground).
+func (m *Map) conductLightning(startTile *types.Tile, heatUp func(pos types.Position, temp float64)) types.Position {
+	visited := map[uint64]bool{startTile.Particle.ID(): true}
+	stack := []*types.Tile{startTile}
+	lowestPos := startTile.Pos
+	for len(stack) > 0 && len(visited) < lightningConductorMax {
+		tile := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		heatUp(tile.Pos, lightningConductedHeat)
+		if tile.Pos.Y > lowestPos.Y {
+			lowestPos = tile.Pos
+		}
+
+		for dx := -1; dx <= 1; dx++ {
+			for dy := -1; dy <= 1; dy++ {
+				x, y := tile.Pos.X+dx, tile.Pos.Y+dy
+				if !m.isPositionValid(x, y) {
+					continue
+				}
+
+				neighbourTile := m.getTile(x, y)
+				if !neighbourTile.HasParticle() || visited[neighbourTile.Particle.ID()] {
+					continue
+				}
+				if !neighbourTile.Particle.Material().IsFlagged(types.MaterialFlagIsConductive) {
+					continue
+				}
+
+				visited[neighbourTile.Particle.ID()] = true
+				stack = append(stack, neighbourTile)
+			}
+		}
+	}
+
+	return types.Position{X: lowestPos.X, Y: lowestPos.Y + 1}
+}
+
+// LightningBolts returns lightning bolt paths (main channels and branches) struck during the last state export.
+func (m *Map) LightningBolts() [][]types.Position {
+	return m.lightningBolts
+}
+
+// clampInt returns the value limited to the [min, max] range.
+func clampInt(v, min, max int) int {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+
+	return v
+}
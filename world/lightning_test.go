@@ -0,0 +1,115 @@
+package world
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/itiky/goPixelWorld/world/materials"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+func TestTraceLightningBolt(t *testing.T) {
+	const (
+		mapWidth  = 40
+		mapHeight = 30
+	)
+
+	type testCase struct {
+		name           string
+		seed           int64
+		startX         int
+		setup          func(m *Map)
+		isStrike       bool
+		dischargePos   *types.Position // expected conductor discharge Position (nil if not a conductor strike)
+		strikeMaterial types.MaterialType
+	}
+
+	testCases := []testCase{
+		{
+			name:     "Empty map: bolt fades",
+			seed:     1,
+			startX:   20,
+			isStrike: false,
+		},
+		{
+			name:   "Sand pile: bolt strikes the top",
+			seed:   2,
+			startX: 10,
+			setup: func(m *Map) {
+				for x := 8; x <= 12; x++ {
+					for y := mapHeight - 6; y < mapHeight-1; y++ {
+						m.createParticle(m.getTile(x, y), materials.NewSand())
+					}
+				}
+			},
+			isStrike:       true,
+			strikeMaterial: types.MaterialTypeSand,
+		},
+		{
+			name:   "Metal column: discharge below the lowest Particle",
+			seed:   3,
+			startX: 15,
+			setup: func(m *Map) {
+				for y := 12; y <= mapHeight-4; y++ {
+					m.createParticle(m.getTile(20, y), materials.NewMetal())
+				}
+			},
+			isStrike:       true,
+			dischargePos:   &types.Position{X: 20, Y: mapHeight - 3},
+			strikeMaterial: types.MaterialTypeMetal,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &Map{
+				particles: make(map[uint64]*types.Tile),
+			}
+			m.initGrid(mapWidth, mapHeight)
+			if tc.setup != nil {
+				tc.setup(m)
+			}
+
+			bolt := m.traceLightningBolt(rand.New(rand.NewSource(tc.seed)), tc.startX)
+			for i := 0; i < 3; i++ {
+				boltRepeated := m.traceLightningBolt(rand.New(rand.NewSource(tc.seed)), tc.startX)
+				if !reflect.DeepEqual(bolt.path, boltRepeated.path) {
+					t.Fatalf("path mismatch: %v != %v", bolt.path, boltRepeated.path)
+				}
+				if !reflect.DeepEqual(bolt.branches, boltRepeated.branches) {
+					t.Fatalf("branches mismatch: %v != %v", bolt.branches, boltRepeated.branches)
+				}
+				if bolt.strikePos != boltRepeated.strikePos {
+					t.Fatalf("strikePos mismatch: %v != %v", bolt.strikePos, boltRepeated.strikePos)
+				}
+			}
+
+			if bolt.isStrike != tc.isStrike {
+				t.Fatalf("isStrike: expected %v, got %v", tc.isStrike, bolt.isStrike)
+			}
+			if len(bolt.path) == 0 || bolt.path[0].Y != 1 {
+				t.Fatalf("path must start at the top: %v", bolt.path)
+			}
+			if !bolt.isStrike {
+				return
+			}
+
+			if lastPos := bolt.path[len(bolt.path)-1]; lastPos != bolt.strikePos {
+				t.Fatalf("strikePos %v is not the last path Position %v", bolt.strikePos, lastPos)
+			}
+			strikeTile := m.getTile(bolt.strikePos.X, bolt.strikePos.Y)
+			if !strikeTile.HasParticle() || strikeTile.Particle.Material().Type() != tc.strikeMaterial {
+				t.Fatalf("strike Tile %v: expected %v", bolt.strikePos, tc.strikeMaterial)
+			}
+
+			if tc.dischargePos == nil {
+				return
+			}
+			dischargePos := m.conductLightning(strikeTile, func(types.Position, float64) {})
+			if dischargePos != *tc.dischargePos {
+				t.Fatalf("dischargePos: expected %v, got %v", *tc.dischargePos, dischargePos)
+			}
+		})
+	}
+}
@@ -21,19 +21,44 @@ var AllMaterialsSet = map[types.MaterialType]types.Material{
 	types.MaterialTypeGraviton:     NewGraviton(),
 	types.MaterialTypeAntiGraviton: NewAntiGraviton(),
 	types.MaterialTypeBug:          NewBug(),
+	types.MaterialTypeOil:          NewOil(),
+	types.MaterialTypeGunpowder:    NewGunpowder(),
+	types.MaterialTypeTNT:          NewTNT(),
+	types.MaterialTypeGlass:        NewGlass(),
+	types.MaterialTypeSnow:         NewSnow(),
+	types.MaterialTypeIce:          NewIce(),
+	types.MaterialTypeSeed:         NewSeed(),
+	types.MaterialTypeTree:         NewTree(),
+	types.MaterialTypeLeaf:         NewLeaf(),
+	types.MaterialTypePredator:     NewPredator(),
+	types.MaterialTypeAnt:          NewAnt(),
+	types.MaterialTypeFish:         NewFish(),
+	types.MaterialTypeAlgae:        NewAlgae(),
+	types.MaterialTypeDeadMatter:   NewDeadMatter(),
+	types.MaterialTypeSoil:         NewSoil(),
+	types.MaterialTypeLava:         NewLava(),
+	types.MaterialTypeObsidian:     NewObsidian(),
 }
 
 type (
 	// base defines common fields and method for all Materials.
 	base struct {
 		// Base params
-		mType     types.MaterialType
-		flags     map[types.MaterialFlag]bool // Material properties set
-		baseColor color.Color                 // the main Particle's color
-		mass      float64                     // Particle's mass
+		mType      types.MaterialType
+		flags      map[types.MaterialFlag]bool // Material properties set
+		baseColor  color.Color                 // the main Particle's color
+		mass       float64                     // Particle's mass
+		thermal    types.MaterialThermal       // heat transfer properties
+		flow       types.MaterialFlow          // liquid / gas flow properties
+		granular   types.MaterialGranular      // sand-like pile properties
+		explosion  types.MaterialExplosion     // explosive properties
+		attraction float64                     // attraction strength for the inverse-square Graviton modes
+		lightK     float64                     // light transmission share [0.0, 1.0] (0.0 - opaque)
 		// Collision processing
 		srcForceDamperK   float64 // source Particle force Vector damper K (the one who has collided to us)
 		srcHealthDampStep float64 // source Particle health damper step
+		// Collision response by the source Material type (overrides srcForceDamperK)
+		collisionPairs map[types.MaterialType]types.MaterialCollisionProps
 		// Self-processing
 		selfHealthInitial  float64                      // Particle's initial health
 		selfHealthDampStep float64                      // Particle health change step
@@ -61,6 +86,68 @@ func withMass(mass float64) baseOpt {
 	}
 }
 
+// withThermal sets a Material heat transfer coefs.
+func withThermal(conductivity, heatCapacity float64) baseOpt {
+	return func(m *base) {
+		m.thermal.Conductivity = conductivity
+		m.thermal.HeatCapacity = heatCapacity
+	}
+}
+
+// withHeatSource makes a Material a heat source which keeps at least the specified temperature.
+func withHeatSource(temperature float64) baseOpt {
+	return func(m *base) {
+		m.thermal.SourceTemperature = temperature
+	}
+}
+
+// withPhaseTransition adds a temperature triggered transition to another Material.
+func withPhaseTransition(change types.MaterialPhaseChange, temperature float64, mType types.MaterialType) baseOpt {
+	return func(m *base) {
+		m.thermal.Transitions = append(m.thermal.Transitions, types.MaterialPhaseTransition{
+			Change:      change,
+			Temperature: temperature,
+			Material:    mType,
+		})
+	}
+}
+
+// withFlow sets a Material liquid / gas flow properties.
+func withFlow(viscosity, dispersionRate float64) baseOpt {
+	return func(m *base) {
+		m.flow.Viscosity = viscosity
+		m.flow.DispersionRate = dispersionRate
+	}
+}
+
+// withExplosion makes a Material explosive (explodes at the trigger temperature).
+func withExplosion(triggerTemperature float64, radius int, impulse, heat, destructionHealth float64) baseOpt {
+	return func(m *base) {
+		m.flags[types.MaterialFlagIsExplosive] = true
+		m.explosion = types.MaterialExplosion{
+			TriggerTemperature: triggerTemperature,
+			Radius:             radius,
+			Impulse:            impulse,
+			Heat:               heat,
+			DestructionHealth:  destructionHealth,
+		}
+	}
+}
+
+// withAttraction sets a Material attraction strength for the inverse-square Graviton modes (negative value repels).
+func withAttraction(strength float64) baseOpt {
+	return func(m *base) {
+		m.attraction = strength
+	}
+}
+
+// withLightTransmission sets a Material light transmission share (the default one is 0.0: opaque).
+func withLightTransmission(k float64) baseOpt {
+	return func(m *base) {
+		m.lightK = k
+	}
+}
+
 // withSourceDamping sets the source Particle damping coefs for collision processing.
 func withSourceDamping(forceK, healthStep float64) baseOpt {
 	return func(m *base) {
@@ -69,6 +156,31 @@ func withSourceDamping(forceK, healthStep float64) baseOpt {
 	}
 }
 
+// withGranular sets a sand-like Material pile properties (angles are in degrees).
+func withGranular(reposeAngle, cohesion, wetReposeAngle, wetCohesion float64) baseOpt {
+	return func(m *base) {
+		m.granular = types.MaterialGranular{
+			ReposeAngle:    pkg.DegToRadAngle(reposeAngle),
+			Cohesion:       cohesion,
+			WetReposeAngle: pkg.DegToRadAngle(wetReposeAngle),
+			WetCohesion:    wetCohesion,
+		}
+	}
+}
+
+// withCollisionPair sets the collision response for a specific source Material (overrides the source force damping).
+func withCollisionPair(sourceType types.MaterialType, restitution, friction float64) baseOpt {
+	return func(m *base) {
+		if m.collisionPairs == nil {
+			m.collisionPairs = make(map[types.MaterialType]types.MaterialCollisionProps)
+		}
+		m.collisionPairs[sourceType] = types.MaterialCollisionProps{
+			Restitution: restitution,
+			Friction:    friction,
+		}
+	}
+}
+
 // withCloseRangeType sets the CloseRange environment build required type for self-processing.
 func withCloseRangeType(closeRangeType types.MaterialCloseRangeType) baseOpt {
 	return func(m *base) {
@@ -100,6 +212,10 @@ func newBase(mType types.MaterialType, baseColor color.Color, opts ...baseOpt) b
 		mass:              100.0,
 		selfHealthInitial: 100.0,
 		closeRangeType:    types.MaterialCloseRangeTypeNone,
+		thermal: types.MaterialThermal{
+			Conductivity: 0.1,
+			HeatCapacity: 1.0,
+		},
 	}
 	for _, opt := range opts {
 		opt(&m)
@@ -112,6 +228,16 @@ func (m base) commonProcessInternal(env types.TileEnvironment) {
 	env.AddWind()
 }
 
+// processExplosion explodes an explosive Particle if it is hot enough.
+// Returns true if the Particle has exploded.
+func (m base) processExplosion(env types.TileEnvironment) bool {
+	if !m.IsFlagged(types.MaterialFlagIsExplosive) || env.Temperature() < m.explosion.TriggerTemperature {
+		return false
+	}
+
+	return env.Explode(m.explosion.Radius, m.explosion.Impulse, m.explosion.Heat, m.explosion.DestructionHealth)
+}
+
 /* The following methods partially implements the types.Material interface */
 
 func (m base) Type() types.MaterialType {
@@ -157,6 +283,31 @@ func (m base) Mass() float64 {
 	return m.mass
 }
 
+func (m base) Thermal() types.MaterialThermal {
+	return m.thermal
+}
+
+func (m base) Flow() types.MaterialFlow {
+	return m.flow
+}
+
+func (m base) Granular() types.MaterialGranular {
+	return m.granular
+}
+
+func (m base) Attraction() float64 {
+	return m.attraction
+}
+
+func (m base) LightTransmission() float64 {
+	return m.lightK
+}
+
+func (m base) CollisionProps(sourceType types.MaterialType) (types.MaterialCollisionProps, bool) {
+	props, ok := m.collisionPairs[sourceType]
+	return props, ok
+}
+
 func (m base) CloseRangeCircleRadius() int {
 	return m.closeRangeCircleR
 }
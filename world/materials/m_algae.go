@@ -0,0 +1,91 @@
+package materials
+
+import (
+	"image/color"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+var _ types.Material = Algae{}
+
+// Algae grows in lit water spreading to neighbour Water Tiles, it is the Fish food.
+// Algae is lighter than Water, so it floats up to the (lit) surface.
+// It withers back into Water in the dark, out of water or being eaten.
+type Algae struct {
+	base
+	minLight        float64 // min light level to grow
+	growthK         float64 // health gained per processing round at the full light
+	witherHealth    float64 // health to wither back into Water
+	spreadHealth    float64 // the amount of health to spread (replace a neighbour Water Tile)
+	spreadMinLiquid int     // min number of liquid neighbours to spread (overcrowding limit)
+}
+
+func NewAlgae() Algae {
+	return Algae{
+		base: newBase(
+			types.MaterialTypeAlgae,
+			color.RGBA{R: 0x2E, G: 0x8B, B: 0x57, A: 0xDF},
+			withCloseRangeType(types.MaterialCloseRangeTypeSurrounding),
+			withMass(8.0),
+			withLightTransmission(0.5),
+			withSelfHealthReduction(30.0, 0.2),
+			withSourceDamping(0.3, 0.0),
+			withThermal(0.4, 3.0),
+			withPhaseTransition(types.MaterialPhaseChangeBoil, 100.0, types.MaterialTypeSteam),
+		),
+		minLight:        0.2,
+		growthK:         1.5,
+		witherHealth:    10.0,
+		spreadHealth:    60.0,
+		spreadMinLiquid: 5,
+	}
+}
+
+func (m Algae) ColorAdjusted(health float64) color.Color {
+	if health < 30.0 {
+		return color.RGBA{R: 0x6B, G: 0x9E, B: 0x5A, A: 0xDF}
+	}
+
+	return m.baseColor
+}
+
+func (m Algae) ProcessInternal(env types.TileEnvironment) {
+	m.commonProcessInternal(env)
+	env.AddGravity()
+	env.AddBuoyancy()
+
+	liquidTiles, _ := env.SearchNeighbours(
+		pkg.ValuePtr(false),
+		nil, false,
+		[]types.MaterialType{types.MaterialTypeWater}, true,
+		nil, false,
+	)
+
+	// Grow in lit water, wither otherwise
+	if light := env.Light(); len(liquidTiles) > 0 && light >= m.minLight {
+		env.DampSelfHealth(-m.growthK * light)
+	} else {
+		env.DampSelfHealth(m.selfHealthDampStep)
+	}
+
+	health := env.Health()
+	if health <= m.witherHealth {
+		if len(liquidTiles) > 0 {
+			env.ReplaceSelf(AllMaterialsSet[types.MaterialTypeWater])
+		} else {
+			env.DampSelfHealth(health)
+		}
+		return
+	}
+
+	if health >= m.spreadHealth && len(liquidTiles) >= m.spreadMinLiquid {
+		if env.ReplaceNeighbourTileByType(AllMaterialsSet[types.MaterialTypeAlgae], []types.MaterialType{types.MaterialTypeWater}) {
+			env.DampSelfHealth(health - m.selfHealthInitial)
+		}
+	}
+}
+
+func (m Algae) ProcessCollision(env types.CollisionEnvironment) {
+	env.ReflectSourceTargetForces(m.srcForceDamperK)
+}
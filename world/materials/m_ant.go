@@ -0,0 +1,215 @@
+package materials
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+var _ types.Material = Ant{}
+
+// Ant lives in a colony: the nest (an Ant painted by the editor) spawns foragers which search for the Grass
+// and bring food bites back home (the nest health is the colony food stock).
+// Foragers lay pheromone trails: a searching one marks the way home, a carrying one marks the way to the food.
+// Both follow the opposite trail, so the path between the food and the nest gets the strongest scent.
+type Ant struct {
+	base
+	speed           float64 // forager walking speed
+	exploreChance   float64 // chance to ignore the scent and pick a random direction
+	headingBonus    float64 // direction score bonus for keeping the current heading
+	trailDeposit    float64 // pheromone amount laid at the trip start
+	trailDecayK     float64 // pheromone amount multiplier per trip step (the trail gets weaker farther from the trip source)
+	tripMax         int     // max trip length (a lost forager turns around)
+	foodBite        float64 // Grass health taken per bite
+	foodValue       float64 // health the nest (and nestmates around) gets per delivered bite
+	nestScent       float64 // home pheromone amount emitted by the nest per processing round
+	nestSpawnHealth float64 // min nest health (food stock) to spawn a new forager
+	nestSpawnCost   float64 // nest health spent per a new forager
+	nestSpawnChance float64 // chance to spawn a new forager per processing round
+	deadNutrients   int     // nutrients left with the Dead Matter on a forager death
+}
+
+// Ant internal state params.
+const (
+	// AntRoleParam defines the colony role (AntRoleNest by default).
+	AntRoleParam = "ant_role"
+	// AntCarryParam is set to 1 if a forager carries food.
+	AntCarryParam = "ant_carry"
+	// AntTripParam defines the number of steps since the last trip source (the nest or food).
+	AntTripParam = "ant_trip"
+	// AntHeadingParam defines the current forager heading (pkg.Direction).
+	AntHeadingParam = "ant_heading"
+)
+
+// Ant colony roles.
+const (
+	AntRoleNest = iota
+	AntRoleForager
+)
+
+func NewAnt() Ant {
+	return Ant{
+		base: newBase(
+			types.MaterialTypeAnt,
+			color.RGBA{R: 0x3A, G: 0x1A, B: 0x10, A: 0xFF},
+			withFlags(types.MaterialFlagIsFlammable),
+			withCloseRangeType(types.MaterialCloseRangeTypeSurrounding),
+			withMass(5.0),
+			withSelfHealthReduction(200.0, 0.1),
+			withSourceDamping(0.5, 0.0),
+			withThermal(0.2, 2.0),
+			withPhaseTransition(types.MaterialPhaseChangeIgnite, 120.0, types.MaterialTypeFire),
+		),
+		speed:           1.0,
+		exploreChance:   0.1,
+		headingBonus:    0.3,
+		trailDeposit:    2.0,
+		trailDecayK:     0.98,
+		tripMax:         400,
+		foodBite:        2.0,
+		foodValue:       15.0,
+		nestScent:       1.0,
+		nestSpawnHealth: 120.0,
+		nestSpawnCost:   40.0,
+		nestSpawnChance: 0.02,
+		deadNutrients:   30,
+	}
+}
+
+func (m Ant) ProcessInternal(env types.TileEnvironment) {
+	if env.StateParam(AntRoleParam) == AntRoleNest {
+		m.processNest(env)
+		return
+	}
+
+	m.commonProcessInternal(env)
+	m.processForager(env)
+}
+
+// processNest emits the home scent and spawns new foragers while there is enough food.
+func (m Ant) processNest(env types.TileEnvironment) {
+	env.AddGravity()
+	env.DepositPheromone(types.PheromoneTypeHome, m.nestScent)
+
+	if env.Health() < m.nestSpawnHealth || rand.Float64() >= m.nestSpawnChance {
+		return
+	}
+
+	forager := AllMaterialsSet[types.MaterialTypeAnt]
+	if env.AddNewNeighbourTileWithState(forager, nil, types.ParticleState{AntRoleParam: AntRoleForager}) {
+		env.DampSelfHealth(m.nestSpawnCost)
+	}
+}
+
+// processForager searches for food (following the food trail) and brings it home (following the home trail).
+func (m Ant) processForager(env types.TileEnvironment) {
+	defer env.AddGravity()
+
+	// Grow old
+	env.DampSelfHealth(m.selfHealthDampStep)
+	if env.Health() <= 0.0 {
+		leaveDeadMatter(env, m.deadNutrients)
+		return
+	}
+
+	isCarrying := env.StateParam(AntCarryParam) == 1
+	trip := env.StateParam(AntTripParam)
+	heading := pkg.Direction(env.StateParam(AntHeadingParam))
+
+	// Trip targets
+	nestNearby := false
+	antTiles, _ := env.SearchNeighbours(
+		pkg.ValuePtr(false),
+		nil, false,
+		[]types.MaterialType{types.MaterialTypeAnt}, true,
+		nil, false,
+	)
+	for _, tile := range antTiles {
+		if tile.Particle.GetStateParam(AntRoleParam) == AntRoleNest {
+			nestNearby = true
+			break
+		}
+	}
+
+	switch {
+	case isCarrying && nestNearby:
+		// Feed the nest (and nestmates around) and go back for more
+		env.DampNeighboursHealthByFlag(-m.foodValue, []types.MaterialType{types.MaterialTypeAnt}, nil)
+		isCarrying, trip, heading = false, 0, heading.Rotate180()
+		env.UpdateStateParam(AntCarryParam, 0)
+	case !isCarrying && env.DampNeighboursHealthByFlag(m.foodBite, []types.MaterialType{types.MaterialTypeGrass}, nil) > 0:
+		// Take a bite and go home
+		isCarrying, trip, heading = true, 0, heading.Rotate180()
+		env.UpdateStateParam(AntCarryParam, 1)
+	case nestNearby:
+		trip = 0
+	}
+
+	// Lay the trail back to the trip source and follow the opposite one
+	trailType, followType := types.PheromoneTypeHome, types.PheromoneTypeFood
+	if isCarrying {
+		trailType, followType = types.PheromoneTypeFood, types.PheromoneTypeHome
+	}
+	if deposit := m.trailDeposit * math.Pow(m.trailDecayK, float64(trip)); deposit >= 0.01 {
+		env.DepositPheromone(trailType, deposit)
+	}
+
+	if trip == m.tripMax {
+		heading = heading.Rotate180()
+	}
+	trip++
+	env.UpdateStateParam(AntTripParam, trip)
+
+	// Walk on the ground only (falling otherwise)
+	groundTiles, _ := env.SearchNeighbours(
+		pkg.ValuePtr(false),
+		[]pkg.Direction{pkg.DirectionBottomLeft, pkg.DirectionBottom, pkg.DirectionBottomRight}, true,
+		nil, false,
+		[]types.MaterialFlag{types.MaterialFlagIsLiquid, types.MaterialFlagIsGas}, false,
+	)
+	if len(groundTiles) == 0 {
+		env.UpdateStateParam(AntHeadingParam, heading.Int())
+		return
+	}
+
+	_, emptyDirs := env.SearchNeighbours(
+		pkg.ValuePtr(true),
+		[]pkg.Direction{pkg.DirectionTop}, false,
+		nil, false,
+		nil, false,
+	)
+	if len(emptyDirs) == 0 {
+		env.UpdateStateParam(AntHeadingParam, heading.Int())
+		return
+	}
+
+	// Pick the next step: the strongest scent with the current heading preferred (or a random one)
+	nextDir := emptyDirs[rand.Intn(len(emptyDirs))]
+	if rand.Float64() >= m.exploreChance {
+		headingSector := heading.Sector(1)
+		bestScore := -1.0
+		for _, dir := range emptyDirs {
+			score := env.Pheromone(followType, dir) + rand.Float64()*0.05
+			for _, headingDir := range headingSector {
+				if dir == headingDir {
+					score += m.headingBonus
+					break
+				}
+			}
+
+			if score > bestScore {
+				nextDir, bestScore = dir, score
+			}
+		}
+	}
+
+	env.UpdateStateParam(AntHeadingParam, nextDir.Int())
+	env.SetSelfForce(pkg.NewVector(m.speed, nextDir.Angle()))
+}
+
+func (m Ant) ProcessCollision(env types.CollisionEnvironment) {
+	env.ReflectSourceTargetForces(m.srcForceDamperK)
+}
@@ -23,6 +23,8 @@ func NewAntiGraviton() AntiGraviton {
 			withMass(1000000.0),
 			withFlags(types.MaterialFlagIsUnmovable),
 			withCloseRangeCircleR(20),
+			withAttraction(-20.0),
+			withThermal(0.0, 1.0),
 		),
 		antiGravityForceMag: -0.7,
 	}
@@ -21,6 +21,7 @@ func NewBorder() Border {
 			withFlags(types.MaterialFlagIsUnremovable, types.MaterialFlagIsUnmovable),
 			withCloseRangeType(types.MaterialCloseRangeTypeNone),
 			withSourceDamping(0.2, 0.0),
+			withThermal(0.0, 1.0),
 		),
 	}
 }
@@ -12,13 +12,16 @@ import (
 var _ types.Material = Bug{}
 
 // Bug ...
+// Movement speed, split health, feed rate, sight and comfort temperature are defined by the per Particle BugGenome.
 type Bug struct {
 	base
-	foodDampHealthStep   float64 // grass surrounding feed speed (the same amount of health is recovered)
 	movementSpeedMagStep float64 // movement speed increment
-	movementSpeedMagMax  float64 // max movement speed limit
-	healthToSplit        float64 // the amount of health to split (create a new Bug)
 	healthAfterSplit     float64 // the amount of health set after the split
+	mutationChance       float64 // per trait mutation chance on split
+	mutationStrength     float64 // per trait mutation step SD (relative to the trait range)
+	comfortTempRange     float64 // temperature deviation from the comfort one with no climate cost
+	climateDampK         float64 // extra health reduction per degree outside the comfort range
+	deadNutrients        int     // nutrients left with the Dead Matter on death
 }
 
 const (
@@ -36,12 +39,16 @@ func NewBug() Bug {
 			withMass(50.0),
 			withSelfHealthReduction(100.0, 0.6),
 			withSourceDamping(0.5, 0.0),
+			withThermal(0.2, 3.0),
+			withPhaseTransition(types.MaterialPhaseChangeIgnite, 120.0, types.MaterialTypeFire),
 		),
-		foodDampHealthStep:   1.0,
 		movementSpeedMagStep: 0.2,
-		movementSpeedMagMax:  1.8,
-		healthToSplit:        1000.0,
 		healthAfterSplit:     20.0,
+		mutationChance:       0.3,
+		mutationStrength:     0.05,
+		comfortTempRange:     10.0,
+		climateDampK:         0.05,
+		deadNutrients:        80,
 	}
 }
 
@@ -64,8 +71,12 @@ func (m Bug) ProcessInternal(env types.TileEnvironment) {
 
 	m.commonProcessInternal(env)
 
+	genome := types.NewBugGenomeFromState(env.StateParam)
+	movementSpeedMagMax := genome[types.BugTraitSpeed]
+	foodDampHealthStep := genome[types.BugTraitFeedRate]
+
 	appendForce := func() {
-		if env.ForceVec().Magnitude() >= m.movementSpeedMagMax {
+		if env.ForceVec().Magnitude() >= movementSpeedMagMax {
 			return
 		}
 		moveVec := pkg.NewVector(m.movementSpeedMagStep, moveDir.Angle())
@@ -74,8 +85,8 @@ func (m Bug) ProcessInternal(env types.TileEnvironment) {
 
 	setForce := func() {
 		moveVec := pkg.NewVector(env.ForceVec().Magnitude()+m.movementSpeedMagStep, moveDir.Angle())
-		if moveVec.Magnitude() >= m.movementSpeedMagMax {
-			moveVec = moveVec.SetMagnitude(m.movementSpeedMagMax)
+		if moveVec.Magnitude() >= movementSpeedMagMax {
+			moveVec = moveVec.SetMagnitude(movementSpeedMagMax)
 		}
 		env.SetSelfForce(moveVec)
 	}
@@ -95,29 +106,28 @@ func (m Bug) ProcessInternal(env types.TileEnvironment) {
 	}()
 
 	// Reduce health
-	env.DampSelfHealth(m.selfHealthDampStep)
+	env.DampSelfHealth(m.metabolismDamp(genome, env.Temperature()))
 	if env.Health() <= 0.0 {
-		if pkg.FlipCoin() {
-			env.ReplaceSelf(AllMaterialsSet[types.MaterialTypeWater])
-		}
+		leaveDeadMatter(env, m.deadNutrients)
 		return
 	}
 
 	// Time to split
-	if curHealth := env.Health(); curHealth >= m.healthToSplit {
+	if curHealth := env.Health(); curHealth >= genome[types.BugTraitSplitHealth] {
 		env.DampSelfHealth(curHealth - m.healthAfterSplit)
-		env.AddNewTileInRange(AllMaterialsSet[types.MaterialTypeBug])
+		childGenome := genome.Mutate(m.mutationChance, m.mutationStrength)
+		env.AddNewTileInRangeWithState(AllMaterialsSet[types.MaterialTypeBug], childGenome.State())
 	}
 
 	// Feed if there is some food around
-	foodTilesInCloseCnt := env.DampEnvHealthByTypeInRange(math.Sqrt2, m.foodDampHealthStep, []types.MaterialType{types.MaterialTypeGrass}, nil)
+	foodTilesInCloseCnt := env.DampEnvHealthByTypeInRange(math.Sqrt2, foodDampHealthStep, []types.MaterialType{types.MaterialTypeGrass}, nil)
 	if foodTilesInCloseCnt > 0 {
 		// Drop the movement intention (everything is OK, food found, no need to move)
 		moveDir = 0
 		env.UpdateStateParam(BugStateParamMovementDir, pkg.DirectionNone.Int())
 		dropForce()
 		// Adjust our health
-		env.DampSelfHealth(-m.foodDampHealthStep * float64(foodTilesInCloseCnt))
+		env.DampSelfHealth(-foodDampHealthStep * float64(foodTilesInCloseCnt))
 		return
 	}
 	// We need to find some
@@ -159,7 +169,7 @@ func (m Bug) ProcessInternal(env types.TileEnvironment) {
 
 	// We are not moving, search for the next food target Tile to move in direction of
 	foodTilesInDistance, _, _ := env.SearchTilesInRange(
-		pkg.ValuePtr(false), pkg.ValuePtr(math.Sqrt2*3),
+		pkg.ValuePtr(false), pkg.ValuePtr(genome[types.BugTraitSight]),
 		nil, false,
 		[]types.MaterialType{types.MaterialTypeGrass}, true,
 		nil, false,
@@ -174,6 +184,14 @@ func (m Bug) ProcessInternal(env types.TileEnvironment) {
 		return
 	}
 
+	// No food in sight, follow the long-range path to the nearest one (if any)
+	if pathDir, _, found := env.FindPath(types.MaterialTypeGrass, types.PathModeWalk); found {
+		moveDir = pathDir
+		env.UpdateStateParam(BugStateParamMovementDir, moveDir.Int())
+		setForce()
+		return
+	}
+
 	// Start moving to a random direction (only if we are standing on smth hard)
 	tiles, tilesDirs, _ := env.SearchTilesInRange(
 		nil, pkg.ValuePtr(math.Sqrt2),
@@ -205,6 +223,23 @@ func (m Bug) ProcessInternal(env types.TileEnvironment) {
 	}
 }
 
+// metabolismDamp returns the health reduction step per processing round.
+// Fast and far-sighted Bugs (and fast feeders) burn more, the climate cost is applied outside the comfort temperature range.
+func (m Bug) metabolismDamp(genome types.BugGenome, temperature float64) float64 {
+	defGenome := types.DefaultBugGenome()
+
+	metabolismK := (genome[types.BugTraitSpeed]/defGenome[types.BugTraitSpeed] +
+		genome[types.BugTraitSight]/defGenome[types.BugTraitSight] +
+		genome[types.BugTraitFeedRate]/defGenome[types.BugTraitFeedRate]) / 3.0
+
+	climateDamp := 0.0
+	if tempDiff := math.Abs(temperature-genome[types.BugTraitComfortTemp]) - m.comfortTempRange; tempDiff > 0.0 {
+		climateDamp = tempDiff * m.climateDampK
+	}
+
+	return m.selfHealthDampStep*metabolismK + climateDamp
+}
+
 func (m Bug) ProcessCollision(env types.CollisionEnvironment) {
 	env.ReflectSourceTargetForces(m.srcForceDamperK)
 	env.DampSelfHealthByMassRate(m.selfHealthDampStep)
@@ -0,0 +1,95 @@
+package materials
+
+import (
+	"image/color"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+var _ types.Material = DeadMatter{}
+
+// DeadMatter is left by dead organisms, it piles like the Sand and decays into a fertile Soil.
+// The decay is faster in a wet environment and it stops in the cold.
+// Nutrients (see NutrientsParam) are passed to the Soil.
+type DeadMatter struct {
+	base
+	defaultNutrients   int     // nutrients amount if it is not set by the organism
+	wetDecayK          float64 // decay rate multiplier next to a liquid
+	dormantTemperature float64 // max temperature of no decay
+}
+
+func NewDeadMatter() DeadMatter {
+	return DeadMatter{
+		base: newBase(
+			types.MaterialTypeDeadMatter,
+			color.RGBA{R: 0x6B, G: 0x5B, B: 0x4A, A: 0xFF},
+			withFlags(types.MaterialFlagIsSand, types.MaterialFlagIsFlammable),
+			withCloseRangeType(types.MaterialCloseRangeTypeSurrounding),
+			withMass(12.0),
+			withSelfHealthReduction(100.0, 0.2),
+			withSourceDamping(0.8, 0.0),
+			withThermal(0.2, 2.0),
+			withGranular(50.0, 0.2, 70.0, 0.5),
+			withPhaseTransition(types.MaterialPhaseChangeIgnite, 200.0, types.MaterialTypeFire),
+		),
+		defaultNutrients:   50,
+		wetDecayK:          2.0,
+		dormantTemperature: 5.0,
+	}
+}
+
+func (m DeadMatter) ColorAdjusted(health float64) color.Color {
+	if health < 50.0 {
+		return color.RGBA{R: 0x5A, G: 0x46, B: 0x33, A: 0xFF}
+	}
+
+	return m.baseColor
+}
+
+func (m DeadMatter) ProcessInternal(env types.TileEnvironment) {
+	m.commonProcessInternal(env)
+	env.AddGravity()
+
+	if env.Temperature() <= m.dormantTemperature {
+		return
+	}
+
+	// Decay
+	decayStep := m.selfHealthDampStep
+	liquidTiles, _ := env.SearchNeighbours(
+		pkg.ValuePtr(false),
+		nil, false,
+		nil, false,
+		[]types.MaterialFlag{types.MaterialFlagIsLiquid}, true,
+	)
+	if len(liquidTiles) > 0 {
+		decayStep *= m.wetDecayK
+	}
+	env.DampSelfHealth(decayStep)
+	if env.Health() > 0.0 {
+		return
+	}
+
+	nutrients := env.StateParam(NutrientsParam)
+	if nutrients == 0 {
+		nutrients = m.defaultNutrients
+	}
+	env.RemoveSelfHealthDamps()
+	env.ReplaceSelfWithState(AllMaterialsSet[types.MaterialTypeSoil], types.ParticleState{NutrientsParam: nutrients})
+}
+
+func (m DeadMatter) ProcessCollision(env types.CollisionEnvironment) {
+	if env.IsFlagged(types.MaterialFlagIsSand) || env.IsFlagged(types.MaterialFlagIsLiquid) {
+		if env.MoveSandSource() {
+			return
+		}
+	}
+	env.ReflectSourceTargetForces(m.srcForceDamperK)
+}
+
+// leaveDeadMatter replaces a dead organism Particle with the Dead Matter keeping the {nutrients} amount.
+func leaveDeadMatter(env types.TileEnvironment, nutrients int) {
+	env.RemoveSelfHealthDamps()
+	env.ReplaceSelfWithState(AllMaterialsSet[types.MaterialTypeDeadMatter], types.ParticleState{NutrientsParam: nutrients})
+}
@@ -10,6 +10,7 @@ import (
 var _ types.Material = Fire{}
 
 // Fire burns itself and surrounding burnable neighbours.
+// Fire is a heat source, so the flammable neighbours are ignited by the temperature.
 // When Fire health is low it replaces itself with the Smoke.
 type Fire struct {
 	base
@@ -27,8 +28,11 @@ func NewFire() Fire {
 			),
 			withCloseRangeType(types.MaterialCloseRangeTypeSurrounding),
 			withMass(1.0),
+			withLightTransmission(1.0),
 			withSelfHealthReduction(100.0, 1.5),
 			withSourceDamping(0.0, 5.0),
+			withThermal(0.5, 1.0),
+			withHeatSource(600.0),
 		),
 		fireDamageDampStep: 2.0,
 	}
@@ -54,11 +58,6 @@ func (m Fire) ProcessInternal(env types.TileEnvironment) {
 	env.DampSelfHealth(m.selfHealthDampStep)
 	env.DampNeighboursHealthByFlag(m.fireDamageDampStep, nil, []types.MaterialFlag{types.MaterialFlagIsFlammable})
 
-	health := env.Health()
-	if health < 30.0 && pkg.RollDice(3) {
-		env.ReplaceNeighbourTile(AllMaterialsSet[types.MaterialTypeFire], []types.MaterialFlag{types.MaterialFlagIsFlammable})
-	}
-
 	if pkg.RollDice(3) {
 		env.AddNewNeighbourTile(AllMaterialsSet[types.MaterialTypeSmoke], nil)
 	}
@@ -0,0 +1,148 @@
+package materials
+
+import (
+	"image/color"
+	"math/rand"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+var _ types.Material = Fish{}
+
+// Fish swims within liquids only (gravity is ignored while it is submerged) eating Algae.
+// Fish swims towards the nearest Algae (long-range pathfinding) or wanders around otherwise.
+// Out of water it flops around and dies quickly.
+// Fish spawns a new one if it is "full" (replacing a neighbour liquid Tile) and leaves the Dead Matter when dies.
+type Fish struct {
+	base
+	swimSpeed         float64 // movement speed within a liquid
+	turnChance        float64 // chance to pick a new random wandering direction
+	biteStep          float64 // Algae health taken per bite (the same amount of health is gained)
+	dryHealthDampStep float64 // health reduction out of water (suffocation)
+	flopChance        float64 // chance to flop (jump) out of water
+	flopForce         float64 // flop force magnitude
+	healthToSplit     float64 // the amount of health to spawn a new Fish
+	healthAfterSplit  float64 // the amount of health set after the spawn
+	deadNutrients     int     // nutrients left with the Dead Matter on death
+}
+
+// FishStateParamHeading defines the current swimming direction (pkg.Direction).
+const FishStateParamHeading = "fish_heading"
+
+func NewFish() Fish {
+	return Fish{
+		base: newBase(
+			types.MaterialTypeFish,
+			color.RGBA{R: 0xFF, G: 0x8C, B: 0x1A, A: 0xFF},
+			withFlags(types.MaterialFlagIsSwimmer),
+			withCloseRangeType(types.MaterialCloseRangeTypeSurrounding),
+			withMass(10.0),
+			withSelfHealthReduction(200.0, 0.3),
+			withSourceDamping(0.5, 0.0),
+			withThermal(0.3, 3.0),
+		),
+		swimSpeed:         1.2,
+		turnChance:        0.05,
+		biteStep:          2.0,
+		dryHealthDampStep: 5.0,
+		flopChance:        0.1,
+		flopForce:         1.5,
+		healthToSplit:     400.0,
+		healthAfterSplit:  200.0,
+		deadNutrients:     100,
+	}
+}
+
+func (m Fish) ColorAdjusted(health float64) color.Color {
+	if health < 50.0 {
+		return color.RGBA{R: 0x99, G: 0x66, B: 0x44, A: 0xFF}
+	} else if health < 100.0 {
+		return color.RGBA{R: 0xCC, G: 0x7A, B: 0x2E, A: 0xFF}
+	}
+
+	return m.baseColor
+}
+
+func (m Fish) ProcessInternal(env types.TileEnvironment) {
+	submerged := env.IsSurroundedByLiquid()
+	if !submerged {
+		// Partially submerged (at the surface) or dry: fall down
+		m.commonProcessInternal(env)
+		env.AddGravity()
+		env.AddBuoyancy()
+	}
+
+	liquidTiles, liquidDirs := env.SearchNeighbours(
+		pkg.ValuePtr(false),
+		nil, false,
+		nil, false,
+		[]types.MaterialFlag{types.MaterialFlagIsLiquid}, true,
+	)
+
+	// Out of water: flop around and suffocate
+	if len(liquidTiles) == 0 {
+		env.DampSelfHealth(m.dryHealthDampStep)
+		if env.Health() <= 0.0 {
+			leaveDeadMatter(env, m.deadNutrients)
+			return
+		}
+
+		if rand.Float64() < m.flopChance {
+			flopDir := pkg.DirectionTopLeft
+			if pkg.FlipCoin() {
+				flopDir = pkg.DirectionTopRight
+			}
+			env.AddSelfForce(pkg.NewVector(m.flopForce, flopDir.Angle()))
+		}
+		return
+	}
+
+	// Grow old
+	env.DampSelfHealth(m.selfHealthDampStep)
+	if env.Health() <= 0.0 {
+		leaveDeadMatter(env, m.deadNutrients)
+		return
+	}
+
+	// Time to spawn
+	if curHealth := env.Health(); curHealth >= m.healthToSplit {
+		if env.ReplaceNeighbourTileByType(AllMaterialsSet[types.MaterialTypeFish], []types.MaterialType{types.MaterialTypeWater}) {
+			env.DampSelfHealth(curHealth - m.healthAfterSplit)
+		}
+	}
+
+	// Eat Algae nearby
+	if biteCnt := env.DampNeighboursHealthByFlag(m.biteStep, []types.MaterialType{types.MaterialTypeAlgae}, nil); biteCnt > 0 {
+		env.DampSelfHealth(-m.biteStep * float64(biteCnt))
+		env.SetSelfForce(pkg.NewVector(0, 0))
+		return
+	}
+
+	// Swim towards the nearest Algae (or keep wandering)
+	heading := pkg.Direction(env.StateParam(FishStateParamHeading))
+	if pathDir, _, found := env.FindPath(types.MaterialTypeAlgae, types.PathModeSwim); found {
+		heading = pathDir
+	} else if rand.Float64() < m.turnChance {
+		heading = pkg.DirectionNone
+	}
+
+	// Stay within the liquid
+	headingIsLiquid := false
+	for _, dir := range liquidDirs {
+		if dir == heading {
+			headingIsLiquid = true
+			break
+		}
+	}
+	if !headingIsLiquid {
+		heading = liquidDirs[rand.Intn(len(liquidDirs))]
+	}
+
+	env.UpdateStateParam(FishStateParamHeading, heading.Int())
+	env.SetSelfForce(pkg.NewVector(m.swimSpeed, heading.Angle()))
+}
+
+func (m Fish) ProcessCollision(env types.CollisionEnvironment) {
+	env.ReflectSourceTargetForces(m.srcForceDamperK)
+}
@@ -0,0 +1,41 @@
+package materials
+
+import (
+	"image/color"
+
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+var _ types.Material = Glass{}
+
+// Glass is a transparent solid (Sand fused by a lightning strike).
+// It is brittle: breaks on hits easier than the Rock does.
+type Glass struct {
+	base
+}
+
+func NewGlass() Glass {
+	return Glass{
+		base: newBase(
+			types.MaterialTypeGlass,
+			color.RGBA{R: 0xC4, G: 0xE8, B: 0xF0, A: 0xC0},
+			withCloseRangeType(types.MaterialCloseRangeTypeSelfOnly),
+			withMass(60.0),
+			withLightTransmission(0.9),
+			withSelfHealthReduction(100.0, 1.5),
+			withSourceDamping(0.8, 0.0),
+			withThermal(0.1, 1.0),
+		),
+	}
+}
+
+func (m Glass) ProcessInternal(env types.TileEnvironment) {
+	m.commonProcessInternal(env)
+
+	env.AddGravity()
+}
+
+func (m Glass) ProcessCollision(env types.CollisionEnvironment) {
+	env.ReflectSourceTargetForces(m.srcForceDamperK)
+	env.DampSelfHealthByMassRate(m.selfHealthDampStep)
+}
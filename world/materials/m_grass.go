@@ -14,12 +14,18 @@ const (
 )
 
 // Grass tries to grow and it is flammable.
-// The growth can be accelerated by water (Grass consumes Water).
-// If it can't grow, it grows old and "dies".
+// The growth rate depends on the light level (no growth in the dark) and can be accelerated by water (Grass consumes Water).
+// If it can't grow, it grows old and "dies" leaving the Dead Matter.
+// Grass is dormant in the cold (winter): it neither grows nor ages.
+// Grass grows faster on a fertile Soil depleting it.
 type Grass struct {
 	base
+	dormantTemperature               float64 // max temperature of the dormancy
 	waterHealthDrainStep             float64 // water surrounding drain
 	surroundingWaterGrowsMultiplierK float64 // health multiplier on growth (the more water around, the faster the growth)
+	fertileGrowthK                   float64 // growth multiplier on a fertile Soil
+	nutrientsDrainChance             float64 // chance to drain a Soil nutrient unit per processing round
+	deadNutrients                    int     // nutrients left with the Dead Matter on death
 }
 
 func NewGrass() Grass {
@@ -32,9 +38,15 @@ func NewGrass() Grass {
 			withMass(7.5),
 			withSelfHealthReduction(5.0, 0.6),
 			withSourceDamping(0.5, 0.0),
+			withThermal(0.2, 1.5),
+			withPhaseTransition(types.MaterialPhaseChangeIgnite, 150.0, types.MaterialTypeFire),
 		),
+		dormantTemperature:               5.0,
 		waterHealthDrainStep:             15.0,
 		surroundingWaterGrowsMultiplierK: 3.0,
+		fertileGrowthK:                   2.0,
+		nutrientsDrainChance:             0.05,
+		deadNutrients:                    10,
 	}
 }
 
@@ -65,19 +77,26 @@ func (m Grass) ProcessInternal(env types.TileEnvironment) {
 		env.AddGravity()
 	}
 
+	if env.Temperature() <= m.dormantTemperature {
+		return
+	}
+
+	light := env.Light()
+	growthK := 1.0
+	if drainSoilNutrients(env, m.nutrientsDrainChance) {
+		growthK = m.fertileGrowthK
+	}
 	if cnt := env.DampNeighboursHealthByFlag(m.waterHealthDrainStep, []types.MaterialType{types.MaterialTypeWater}, nil); cnt > 0 {
-		env.DampSelfHealth(-m.surroundingWaterGrowsMultiplierK * float64(cnt))
+		env.DampSelfHealth(-m.surroundingWaterGrowsMultiplierK * float64(cnt) * light * growthK)
 	} else {
 		healthChange := m.selfHealthDampStep
 		if env.StateParam(GrassGrowDirParam) == 0 {
-			healthChange *= -1.0
+			healthChange *= -light * growthK
 		}
 		env.DampSelfHealth(healthChange)
 
 		if env.Health() <= 0.0 {
-			if pkg.RollDice(3) {
-				env.AddNewNeighbourTile(AllMaterialsSet[types.MaterialTypeWater], nil)
-			}
+			leaveDeadMatter(env, m.deadNutrients)
 			return
 		}
 	}
@@ -23,6 +23,8 @@ func NewGraviton() Graviton {
 			withMass(1000000.0),
 			withFlags(types.MaterialFlagIsUnmovable),
 			withCloseRangeCircleR(20),
+			withAttraction(20.0),
+			withThermal(0.0, 1.0),
 		),
 		gravityForceMag: 0.7,
 	}
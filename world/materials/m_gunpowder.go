@@ -0,0 +1,49 @@
+package materials
+
+import (
+	"image/color"
+
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+var _ types.Material = Gunpowder{}
+
+// Gunpowder spreads like a sand and explodes when heated (by Fire, for ex.).
+// The blast is weak, but heats up the surrounding Gunpowder making a chain reaction.
+type Gunpowder struct {
+	base
+}
+
+func NewGunpowder() Gunpowder {
+	return Gunpowder{
+		base: newBase(
+			types.MaterialTypeGunpowder,
+			color.RGBA{R: 0x3C, G: 0x3C, B: 0x44, A: 0xFF},
+			withFlags(types.MaterialFlagIsSand),
+			withCloseRangeType(types.MaterialCloseRangeTypeSelfOnly),
+			withMass(12.0),
+			withSourceDamping(0.9, 0.0),
+			withThermal(0.3, 1.0),
+			withGranular(35.0, 0.0, 60.0, 0.3),
+			withExplosion(150.0, 3, 2.0, 500.0, 30.0),
+		),
+	}
+}
+
+func (m Gunpowder) ProcessInternal(env types.TileEnvironment) {
+	if m.processExplosion(env) {
+		return
+	}
+	m.commonProcessInternal(env)
+
+	env.AddGravity()
+}
+
+func (m Gunpowder) ProcessCollision(env types.CollisionEnvironment) {
+	if env.IsFlagged(types.MaterialFlagIsSand) || env.IsFlagged(types.MaterialFlagIsLiquid) {
+		if env.MoveSandSource() {
+			return
+		}
+	}
+	env.ReflectSourceTargetForces(m.srcForceDamperK)
+}
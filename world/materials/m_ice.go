@@ -0,0 +1,39 @@
+package materials
+
+import (
+	"image/color"
+
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+var _ types.Material = Ice{}
+
+// Ice is a frozen Water: a solid which doesn't move and melts back into Water when heated (by the Fire or a warm season).
+// It is slippery: other Particles slide on it.
+type Ice struct {
+	base
+}
+
+func NewIce() Ice {
+	return Ice{
+		base: newBase(
+			types.MaterialTypeIce,
+			color.RGBA{R: 0xA5, G: 0xD8, B: 0xF2, A: 0xE0},
+			withFlags(types.MaterialFlagIsUnmovable),
+			withMass(1000.0),
+			withLightTransmission(0.7),
+			withSourceDamping(0.9, 0.0),
+			withThermal(0.5, 2.0),
+			withCollisionPair(types.MaterialTypeSand, 0.2, 0.0),
+			withCollisionPair(types.MaterialTypeSnow, 0.2, 0.0),
+			withCollisionPair(types.MaterialTypeRock, 0.2, 0.0),
+			withCollisionPair(types.MaterialTypeGlass, 0.2, 0.0),
+			withCollisionPair(types.MaterialTypeBug, 0.2, 0.0),
+			withPhaseTransition(types.MaterialPhaseChangeMelt, 5.0, types.MaterialTypeWater),
+		),
+	}
+}
+
+func (m Ice) ProcessCollision(env types.CollisionEnvironment) {
+	env.ReflectSourceTargetForces(m.srcForceDamperK)
+}
@@ -0,0 +1,97 @@
+package materials
+
+import (
+	"image/color"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+var _ types.Material = Lava{}
+
+// Lava is a viscous hot liquid: it flows slowly and ignites flammable neighbours.
+// Lava is a heat source, its health defines how hot it is (the glow color), exposed Lava cools faster (crust).
+// Lava cools into the Rock over time or instantly into the Obsidian on contact with Water (turning it into Steam).
+type Lava struct {
+	base
+	burnDampStep  float64 // flammable surrounding damage
+	crustDampStep float64 // extra cooling (health reduction) if exposed to an empty neighbour
+}
+
+func NewLava() Lava {
+	return Lava{
+		base: newBase(
+			types.MaterialTypeLava,
+			color.RGBA{R: 0xFF, G: 0xE0, B: 0x40, A: 0xFF},
+			withFlags(types.MaterialFlagIsLiquid),
+			withCloseRangeType(types.MaterialCloseRangeTypeSurrounding),
+			withMass(30.0),
+			withSelfHealthReduction(300.0, 0.1),
+			withSourceDamping(0.1, 0.0),
+			withThermal(0.4, 5.0),
+			withHeatSource(1000.0),
+			withFlow(0.85, 0.1),
+		),
+		burnDampStep:  3.0,
+		crustDampStep: 0.4,
+	}
+}
+
+func (m Lava) ColorAdjusted(health float64) color.Color {
+	if health < 50.0 {
+		return color.RGBA{R: 0x6E, G: 0x14, B: 0x0A, A: 0xFF}
+	} else if health < 100.0 {
+		return color.RGBA{R: 0xA8, G: 0x20, B: 0x08, A: 0xFF}
+	} else if health < 175.0 {
+		return color.RGBA{R: 0xE0, G: 0x48, B: 0x10, A: 0xFF}
+	} else if health < 250.0 {
+		return color.RGBA{R: 0xFF, G: 0x8C, B: 0x1E, A: 0xFF}
+	}
+
+	return m.baseColor
+}
+
+func (m Lava) ProcessInternal(env types.TileEnvironment) {
+	m.commonProcessInternal(env)
+
+	// Quench by Water
+	if env.ReplaceNeighbourTileByType(AllMaterialsSet[types.MaterialTypeSteam], []types.MaterialType{types.MaterialTypeWater}) {
+		env.ReplaceSelf(AllMaterialsSet[types.MaterialTypeObsidian])
+		return
+	}
+
+	// Cool down (faster if exposed)
+	coolStep := m.selfHealthDampStep
+	emptyTiles, _ := env.SearchNeighbours(
+		pkg.ValuePtr(true),
+		nil, false,
+		nil, false,
+		nil, false,
+	)
+	if len(emptyTiles) > 0 {
+		coolStep += m.crustDampStep
+	}
+	env.DampSelfHealth(coolStep)
+	if env.Health() <= 0.0 {
+		env.RemoveSelfHealthDamps()
+		env.ReplaceSelf(AllMaterialsSet[types.MaterialTypeRock])
+		return
+	}
+
+	// Burn the surrounding
+	env.DampNeighboursHealthByFlag(m.burnDampStep, nil, []types.MaterialFlag{types.MaterialFlagIsFlammable})
+
+	env.AddGravity()
+	env.MoveTileWithPressure()
+}
+
+func (m Lava) ProcessCollision(env types.CollisionEnvironment) {
+	env.DampSourceHealth(m.burnDampStep, types.MaterialFlagIsFlammable)
+
+	if env.IsFlagged(types.MaterialFlagIsLiquid) {
+		if env.MoveLiquidSource() {
+			return
+		}
+	}
+	env.DampSourceForce(m.srcForceDamperK)
+}
@@ -0,0 +1,99 @@
+package materials
+
+import (
+	"image/color"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+var _ types.Material = Leaf{}
+
+const (
+	LeafAttachedParam = "leaf_attached" // 1 - attached to a Tree, 0 - fallen
+	LeafSpreadParam   = "leaf_spread"   // number of Leaf generations left to sprout (the canopy radius)
+)
+
+// Leaf is sprouted by a Tree and it is flammable.
+// An attached Leaf spreads into a canopy and doesn't move.
+// It falls in the cold (autumn) or when its Tree dies, a fallen Leaf is blown by the wind and decays.
+type Leaf struct {
+	base
+	fallTemperature  float64 // max temperature to fall
+	detachSpreadStep float64 // falling Leaf damage to the neighbouring Leaves (a dead Tree loses the whole canopy)
+	deadNutrients    int     // nutrients left with the Dead Matter when a fallen Leaf rots
+}
+
+func NewLeaf() Leaf {
+	return Leaf{
+		base: newBase(
+			types.MaterialTypeLeaf,
+			color.RGBA{R: 0x2E, G: 0x8B, B: 0x20, A: 0xFF},
+			withFlags(types.MaterialFlagIsFlammable),
+			withCloseRangeType(types.MaterialCloseRangeTypeSurrounding),
+			withMass(1.0),
+			withLightTransmission(0.7),
+			withSelfHealthReduction(100.0, 0.1),
+			withSourceDamping(0.3, 0.0),
+			withThermal(0.2, 1.0),
+			withPhaseTransition(types.MaterialPhaseChangeIgnite, 150.0, types.MaterialTypeFire),
+		),
+		fallTemperature:  10.0,
+		detachSpreadStep: 1.0,
+		deadNutrients:    20,
+	}
+}
+
+func (m Leaf) ColorAdjusted(health float64) color.Color {
+	// Fallen Leaves turn yellow and brown while decaying
+	if health < 40.0 {
+		return color.RGBA{R: 0x7A, G: 0x4A, B: 0x1A, A: 0xFF}
+	} else if health < 80.0 {
+		return color.RGBA{R: 0xC8, G: 0x6A, B: 0x1C, A: 0xFF}
+	} else if health < 100.0 {
+		return color.RGBA{R: 0xD8, G: 0xB0, B: 0x20, A: 0xFF}
+	}
+
+	return m.baseColor
+}
+
+func (m Leaf) ProcessInternal(env types.TileEnvironment) {
+	if env.StateParam(LeafAttachedParam) == 1 {
+		// Fall (damaged by a dying Tree / neighbouring falling Leaf or it is cold)
+		if env.Health() < m.selfHealthInitial || env.Temperature() <= m.fallTemperature {
+			env.UpdateStateParam(LeafAttachedParam, 0)
+			env.DampNeighboursHealthByFlag(m.detachSpreadStep, []types.MaterialType{types.MaterialTypeLeaf}, nil)
+			env.DampSelfHealth(m.selfHealthDampStep)
+			return
+		}
+
+		// Keep in place and spread the canopy
+		env.SetSelfForce(pkg.NewVector(0, 0))
+		if spread := env.StateParam(LeafSpreadParam); spread > 0 {
+			env.AddNewNeighbourTileWithState(m, nil, types.ParticleState{
+				LeafAttachedParam: 1,
+				LeafSpreadParam:   spread - 1,
+			})
+			env.UpdateStateParam(LeafSpreadParam, spread-1)
+		}
+		return
+	}
+
+	m.commonProcessInternal(env)
+
+	// Rot
+	env.AddGravity()
+	env.DampSelfHealth(m.selfHealthDampStep)
+	if env.Health() <= 0.0 {
+		leaveDeadMatter(env, m.deadNutrients)
+	}
+}
+
+func (m Leaf) ProcessCollision(env types.CollisionEnvironment) {
+	if env.IsFlagged(types.MaterialFlagIsSand) || env.IsFlagged(types.MaterialFlagIsLiquid) {
+		if env.MoveSandSource() {
+			return
+		}
+	}
+	env.DampSourceForce(m.srcForceDamperK)
+}
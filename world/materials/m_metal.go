@@ -9,6 +9,7 @@ import (
 var _ types.Material = Metal{}
 
 // Metal is very strong and reflects other Particles with a very low force damping.
+// Metal conducts electricity (a lightning strike).
 type Metal struct {
 	base
 }
@@ -18,9 +19,11 @@ func NewMetal() Metal {
 		base: newBase(
 			types.MaterialTypeMetal,
 			color.RGBA{R: 0xBD, G: 0xC9, B: 0xBE, A: 0xFF},
-			withFlags(types.MaterialFlagIsUnmovable),
+			withFlags(types.MaterialFlagIsUnmovable, types.MaterialFlagIsConductive),
 			withMass(100000.0),
 			withSourceDamping(0.7, 0.0),
+			withThermal(0.9, 1.5),
+			withCollisionPair(types.MaterialTypeSand, 0.1, 0.0),
 		),
 	}
 }
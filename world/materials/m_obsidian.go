@@ -0,0 +1,41 @@
+package materials
+
+import (
+	"image/color"
+
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+var _ types.Material = Obsidian{}
+
+// Obsidian is a glassy solid (Lava quenched by Water).
+// It is harder than the Rock and doesn't erode.
+type Obsidian struct {
+	base
+}
+
+func NewObsidian() Obsidian {
+	return Obsidian{
+		base: newBase(
+			types.MaterialTypeObsidian,
+			color.RGBA{R: 0x1E, G: 0x16, B: 0x2C, A: 0xFF},
+			withCloseRangeType(types.MaterialCloseRangeTypeSelfOnly),
+			withMass(120.0),
+			withLightTransmission(0.1),
+			withSelfHealthReduction(100.0, 0.2),
+			withSourceDamping(0.9, 0.0),
+			withThermal(0.2, 2.0),
+		),
+	}
+}
+
+func (m Obsidian) ProcessInternal(env types.TileEnvironment) {
+	m.commonProcessInternal(env)
+
+	env.AddGravity()
+}
+
+func (m Obsidian) ProcessCollision(env types.CollisionEnvironment) {
+	env.ReflectSourceTargetForces(m.srcForceDamperK)
+	env.DampSelfHealthByMassRate(m.selfHealthDampStep)
+}
@@ -0,0 +1,48 @@
+package materials
+
+import (
+	"image/color"
+
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+var _ types.Material = Oil{}
+
+// Oil is a viscous and flammable liquid.
+// It is lighter than the Water, so it floats on the surface.
+type Oil struct {
+	base
+}
+
+func NewOil() Oil {
+	return Oil{
+		base: newBase(
+			types.MaterialTypeOil,
+			color.RGBA{R: 0x4A, G: 0x3B, B: 0x12, A: 0xE0},
+			withFlags(types.MaterialFlagIsLiquid, types.MaterialFlagIsFlammable),
+			withCloseRangeType(types.MaterialCloseRangeTypeSurrounding),
+			withMass(8.0),
+			withLightTransmission(0.4),
+			withSourceDamping(0.2, 0.0),
+			withThermal(0.15, 2.0),
+			withFlow(0.4, 0.3),
+			withPhaseTransition(types.MaterialPhaseChangeIgnite, 200.0, types.MaterialTypeFire),
+		),
+	}
+}
+
+func (m Oil) ProcessInternal(env types.TileEnvironment) {
+	m.commonProcessInternal(env)
+
+	env.AddGravity()
+	env.MoveTileWithPressure()
+}
+
+func (m Oil) ProcessCollision(env types.CollisionEnvironment) {
+	if env.IsFlagged(types.MaterialFlagIsLiquid) {
+		if env.MoveLiquidSource() {
+			return
+		}
+	}
+	env.DampSourceForce(m.srcForceDamperK)
+}
@@ -0,0 +1,154 @@
+package materials
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+var _ types.Material = Predator{}
+
+// Predator hunts Bugs: it chases the closest Bug in sight steering towards it and eats it gaining its health.
+// Predator rests while digesting, splits if it is "full" or starves otherwise.
+type Predator struct {
+	base
+	speedMax         float64 // max movement speed
+	steeringK        float64 // desired velocity correction rate (the higher, the sharper turns)
+	wanderSpeed      float64 // movement speed while there is no prey in sight
+	sightRadius      float64 // prey search radius
+	absorbK          float64 // prey health part gained on eating
+	digestRounds     int     // number of processing rounds to rest after eating
+	healthToSplit    float64 // the amount of health to split (create a new Predator)
+	healthAfterSplit float64 // the amount of health set after the split
+	deadNutrients    int     // nutrients left with the Dead Matter on death
+}
+
+const (
+	// PredatorStateParamDigest defines the number of processing rounds left to digest the prey.
+	PredatorStateParamDigest = "predator_digest"
+	// PredatorStateParamWanderDir defines the wandering direction (-1 / +1 horizontally, 0 if not set).
+	PredatorStateParamWanderDir = "predator_wander_dir"
+)
+
+func NewPredator() Predator {
+	return Predator{
+		base: newBase(
+			types.MaterialTypePredator,
+			color.RGBA{R: 0xC8, G: 0x1E, B: 0x5A, A: 0xFF},
+			withFlags(types.MaterialFlagIsFlammable),
+			withCloseRangeType(types.MaterialCloseRangeTypeInCircleRange),
+			withCloseRangeCircleR(8),
+			withMass(80.0),
+			withSelfHealthReduction(300.0, 1.0),
+			withSourceDamping(0.5, 0.0),
+			withThermal(0.2, 3.0),
+			withPhaseTransition(types.MaterialPhaseChangeIgnite, 120.0, types.MaterialTypeFire),
+		),
+		speedMax:         2.2,
+		steeringK:        0.3,
+		wanderSpeed:      0.6,
+		sightRadius:      8.0,
+		absorbK:          0.8,
+		digestRounds:     40,
+		healthToSplit:    1200.0,
+		healthAfterSplit: 300.0,
+		deadNutrients:    200,
+	}
+}
+
+func (m Predator) ColorAdjusted(health float64) color.Color {
+	if health < 100.0 {
+		return color.RGBA{R: 0x7A, G: 0x2A, B: 0x44, A: 0xFF}
+	} else if health < 200.0 {
+		return color.RGBA{R: 0xA0, G: 0x24, B: 0x50, A: 0xFF}
+	}
+
+	return m.baseColor
+}
+
+func (m Predator) ProcessInternal(env types.TileEnvironment) {
+	m.commonProcessInternal(env)
+	defer env.AddGravity()
+
+	// Starve
+	env.DampSelfHealth(m.selfHealthDampStep)
+	if env.Health() <= 0.0 {
+		leaveDeadMatter(env, m.deadNutrients)
+		return
+	}
+
+	// Time to split
+	if curHealth := env.Health(); curHealth >= m.healthToSplit {
+		env.DampSelfHealth(curHealth - m.healthAfterSplit)
+		env.AddNewTileInRange(AllMaterialsSet[types.MaterialTypePredator])
+	}
+
+	// Rest while digesting
+	if digestLeft := env.StateParam(PredatorStateParamDigest); digestLeft > 0 {
+		env.UpdateStateParam(PredatorStateParamDigest, digestLeft-1)
+		env.SetSelfForce(pkg.NewVector(0, 0))
+		return
+	}
+
+	// Eat a Bug nearby
+	if env.ConsumeTileInRange(math.Sqrt2, []types.MaterialType{types.MaterialTypeBug}, m.absorbK) {
+		env.UpdateStateParam(PredatorStateParamDigest, m.digestRounds)
+		env.SetSelfForce(pkg.NewVector(0, 0))
+		return
+	}
+
+	// Chase the closest Bug in sight
+	preyTiles, _, _ := env.SearchTilesInRange(
+		pkg.ValuePtr(false), pkg.ValuePtr(m.sightRadius),
+		nil, false,
+		[]types.MaterialType{types.MaterialTypeBug}, true,
+		nil, false,
+	)
+	if len(preyTiles) > 0 {
+		pos := env.Position()
+		targetPos, targetDist := preyTiles[0].Pos, math.MaxFloat64
+		for _, tile := range preyTiles {
+			if dist := math.Hypot(float64(tile.Pos.X-pos.X), float64(tile.Pos.Y-pos.Y)); dist < targetDist {
+				targetPos, targetDist = tile.Pos, dist
+			}
+		}
+
+		m.steer(env, pkg.NewVectorByCoordinates(m.speedMax, float64(pos.X), float64(pos.Y), float64(targetPos.X), float64(targetPos.Y)))
+		return
+	}
+
+	// Wander around (turn back if blocked)
+	wanderDir := env.StateParam(PredatorStateParamWanderDir)
+	if wanderDir == 0 || env.StateParam(types.ParticleStateParamSteady) > 5 {
+		wanderDir = 1
+		if rand.Intn(2) == 0 {
+			wanderDir = -1
+		}
+		env.UpdateStateParam(PredatorStateParamWanderDir, wanderDir)
+	}
+	wanderAngle := float64(pkg.Rad0)
+	if wanderDir < 0 {
+		wanderAngle = pkg.Rad180
+	}
+	m.steer(env, pkg.NewVector(m.wanderSpeed, wanderAngle))
+}
+
+// steer corrects the current velocity towards the desired one (limited by the max speed).
+func (m Predator) steer(env types.TileEnvironment, desiredVec pkg.Vector) {
+	curVec := env.ForceVec()
+	steeringVec := desiredVec.Add(curVec.MultiplyByK(-1.0)).MultiplyByK(m.steeringK)
+
+	newVec := curVec.Add(steeringVec)
+	if newVec.Magnitude() > m.speedMax {
+		newVec = newVec.SetMagnitude(m.speedMax)
+	}
+	env.SetSelfForce(newVec)
+}
+
+func (m Predator) ProcessCollision(env types.CollisionEnvironment) {
+	env.ReflectSourceTargetForces(m.srcForceDamperK)
+	env.DampSelfHealthByMassRate(m.selfHealthDampStep)
+}
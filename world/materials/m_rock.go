@@ -9,6 +9,7 @@ import (
 var _ types.Material = Rock{}
 
 // Rock can be destructed by other Particles (including itself) depending on how heavy they are.
+// Rock slowly erodes into a (poor) Soil under Water (see Water).
 type Rock struct {
 	base
 }
@@ -22,6 +23,8 @@ func NewRock() Rock {
 			withMass(100.0),
 			withSelfHealthReduction(100.0, 0.5),
 			withSourceDamping(0.9, 0.0),
+			withThermal(0.3, 2.0),
+			withCollisionPair(types.MaterialTypeBug, 0.6, 0.1),
 		),
 	}
 }
@@ -9,6 +9,7 @@ import (
 var _ types.Material = Sand{}
 
 // Sand spreads like a sand.
+// Wet Sand (next to a liquid) holds steeper slopes.
 type Sand struct {
 	base
 }
@@ -20,8 +21,11 @@ func NewSand() Sand {
 			color.RGBA{R: 0xFF, G: 0xD5, B: 0x00, A: 0xFF},
 			withFlags(types.MaterialFlagIsSand),
 			withCloseRangeType(types.MaterialCloseRangeTypeSelfOnly),
-			withMass(5.0),
+			withMass(15.0),
 			withSourceDamping(0.9, 0.0),
+			withThermal(0.2, 2.0),
+			withGranular(40.0, 0.0, 70.0, 0.3),
+			withCollisionPair(types.MaterialTypeSand, 0.0, 1.0),
 		),
 	}
 }
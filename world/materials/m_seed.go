@@ -0,0 +1,88 @@
+package materials
+
+import (
+	"image/color"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+var _ types.Material = Seed{}
+
+// seedSoilTypes defines Material types a Seed can sprout on.
+var seedSoilTypes = []types.MaterialType{types.MaterialTypeSand, types.MaterialTypeGrass, types.MaterialTypeSoil}
+
+// Seed falls like the Sand and sprouts into a Tree when it rests on a soil with Water nearby.
+// Seed keeps the TreeGenome passed to the Tree, it dies if it can't sprout for too long.
+type Seed struct {
+	base
+	genome             types.TreeGenome
+	tree               Tree    // the Tree Material to sprout into (keeps the genome)
+	dormantTemperature float64 // max temperature of the dormancy (no sprouting)
+}
+
+func NewSeed() Seed {
+	return NewSeedWithGenome(types.DefaultTreeGenome())
+}
+
+// NewSeedWithGenome creates a new Seed Material with a custom TreeGenome.
+func NewSeedWithGenome(genome types.TreeGenome) Seed {
+	return Seed{
+		base: newBase(
+			types.MaterialTypeSeed,
+			color.RGBA{R: 0xA0, G: 0x6A, B: 0x2C, A: 0xFF},
+			withFlags(types.MaterialFlagIsSand, types.MaterialFlagIsFlammable),
+			withCloseRangeType(types.MaterialCloseRangeTypeSurrounding),
+			withMass(3.0),
+			withSelfHealthReduction(100.0, 0.02),
+			withSourceDamping(0.6, 0.0),
+			withThermal(0.1, 1.0),
+			withPhaseTransition(types.MaterialPhaseChangeIgnite, 150.0, types.MaterialTypeFire),
+		),
+		genome:             genome,
+		tree:               NewTreeWithGenome(genome),
+		dormantTemperature: 5.0,
+	}
+}
+
+func (m Seed) ProcessInternal(env types.TileEnvironment) {
+	m.commonProcessInternal(env)
+
+	env.AddGravity()
+	env.DampSelfHealth(m.selfHealthDampStep)
+
+	// Sprout (only a resting Seed)
+	if env.StateParam(types.ParticleStateParamSteady) == 0 || env.Temperature() <= m.dormantTemperature {
+		return
+	}
+	soilTiles, _ := env.SearchNeighbours(
+		pkg.ValuePtr(false),
+		[]pkg.Direction{pkg.DirectionBottom}, true,
+		seedSoilTypes, true,
+		nil, false,
+	)
+	if len(soilTiles) == 0 {
+		return
+	}
+	waterTiles, _ := env.SearchNeighbours(
+		pkg.ValuePtr(false),
+		nil, false,
+		[]types.MaterialType{types.MaterialTypeWater}, true,
+		nil, false,
+	)
+	if len(waterTiles) == 0 {
+		return
+	}
+
+	env.RemoveSelfHealthDamps()
+	env.ReplaceSelf(m.tree)
+}
+
+func (m Seed) ProcessCollision(env types.CollisionEnvironment) {
+	if env.IsFlagged(types.MaterialFlagIsSand) || env.IsFlagged(types.MaterialFlagIsLiquid) {
+		if env.MoveSandSource() {
+			return
+		}
+	}
+	env.ReflectSourceTargetForces(m.srcForceDamperK)
+}
@@ -19,9 +19,12 @@ func NewSmoke() Smoke {
 			types.MaterialTypeSmoke,
 			color.RGBA{R: 0xCD, G: 0xCD, B: 0xCD, A: 0xFF},
 			withFlags(types.MaterialFlagIsGas),
-			withCloseRangeType(types.MaterialCloseRangeTypeSelfOnly),
+			withCloseRangeType(types.MaterialCloseRangeTypeSurrounding),
 			withMass(2.0),
+			withLightTransmission(0.5),
 			withSelfHealthReduction(100.0, 0.5),
+			withThermal(0.1, 1.0),
+			withFlow(0.0, 0.3),
 		),
 	}
 }
@@ -44,13 +47,12 @@ func (m Smoke) ProcessInternal(env types.TileEnvironment) {
 	m.commonProcessInternal(env)
 
 	env.AddReverseGravity()
+	env.MoveTileWithPressure()
 	env.DampSelfHealth(m.selfHealthDampStep)
 }
 
 func (m Smoke) ProcessCollision(env types.CollisionEnvironment) {
 	if env.IsFlagged(types.MaterialFlagIsGas) {
 		env.MoveSandSource()
-		return
 	}
-	env.SwapSourceTarget()
 }
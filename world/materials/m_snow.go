@@ -0,0 +1,76 @@
+package materials
+
+import (
+	"image/color"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+var _ types.Material = Snow{}
+
+const (
+	SnowCompactionParam = "snow_compaction" // number of processing rounds the Snow was resting under a load
+)
+
+// Snow falls and piles like the Sand (holding steeper slopes) and melts into Water.
+// Resting Snow under another Snow compacts with time into Ice.
+type Snow struct {
+	base
+	compactionMax int // number of processing rounds under a load to compact into Ice
+}
+
+func NewSnow() Snow {
+	return Snow{
+		base: newBase(
+			types.MaterialTypeSnow,
+			color.RGBA{R: 0xF0, G: 0xF8, B: 0xFF, A: 0xFF},
+			withFlags(types.MaterialFlagIsSand),
+			withCloseRangeType(types.MaterialCloseRangeTypeSurrounding),
+			withMass(4.0),
+			withLightTransmission(0.3),
+			withSourceDamping(0.6, 0.0),
+			withThermal(0.1, 2.0),
+			withGranular(55.0, 0.2, 70.0, 0.4),
+			withCollisionPair(types.MaterialTypeSnow, 0.0, 1.0),
+			withPhaseTransition(types.MaterialPhaseChangeMelt, 2.0, types.MaterialTypeWater),
+		),
+		compactionMax: 1500,
+	}
+}
+
+func (m Snow) ProcessInternal(env types.TileEnvironment) {
+	m.commonProcessInternal(env)
+
+	env.AddGravity()
+
+	// Compaction (only a resting Snow under a load)
+	if env.StateParam(types.ParticleStateParamSteady) == 0 {
+		return
+	}
+	loadTiles, _ := env.SearchNeighbours(
+		pkg.ValuePtr(false),
+		[]pkg.Direction{pkg.DirectionTop}, true,
+		[]types.MaterialType{types.MaterialTypeSnow, types.MaterialTypeIce}, true,
+		nil, false,
+	)
+	if len(loadTiles) == 0 {
+		return
+	}
+
+	compaction := env.StateParam(SnowCompactionParam) + 1
+	if compaction >= m.compactionMax {
+		env.ReplaceSelf(AllMaterialsSet[types.MaterialTypeIce])
+		return
+	}
+	env.UpdateStateParam(SnowCompactionParam, compaction)
+}
+
+func (m Snow) ProcessCollision(env types.CollisionEnvironment) {
+	if env.IsFlagged(types.MaterialFlagIsSand) || env.IsFlagged(types.MaterialFlagIsLiquid) {
+		if env.MoveSandSource() {
+			return
+		}
+	}
+	env.ReflectSourceTargetForces(m.srcForceDamperK)
+}
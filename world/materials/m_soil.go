@@ -0,0 +1,77 @@
+package materials
+
+import (
+	"image/color"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+var _ types.Material = Soil{}
+
+// NutrientsParam defines the amount of nutrients kept by a Particle (Soil fertility, Dead Matter nutrients passed to the Soil).
+const NutrientsParam = "nutrients"
+
+// Soil piles like the Sand, it is the ground for plants.
+// Soil nutrients come from the decayed Dead Matter (and eroded Rock), plants growing on a fertile Soil deplete them.
+type Soil struct {
+	base
+}
+
+func NewSoil() Soil {
+	return Soil{
+		base: newBase(
+			types.MaterialTypeSoil,
+			color.RGBA{R: 0x4E, G: 0x34, B: 0x1F, A: 0xFF},
+			withFlags(types.MaterialFlagIsSand),
+			withCloseRangeType(types.MaterialCloseRangeTypeSelfOnly),
+			withMass(14.0),
+			withSourceDamping(0.9, 0.0),
+			withThermal(0.2, 2.5),
+			withGranular(45.0, 0.1, 70.0, 0.4),
+			withCollisionPair(types.MaterialTypeSoil, 0.0, 1.0),
+		),
+	}
+}
+
+func (m Soil) ProcessInternal(env types.TileEnvironment) {
+	m.commonProcessInternal(env)
+
+	env.AddGravity()
+}
+
+func (m Soil) ProcessCollision(env types.CollisionEnvironment) {
+	if env.IsFlagged(types.MaterialFlagIsSand) || env.IsFlagged(types.MaterialFlagIsLiquid) {
+		if env.MoveSandSource() {
+			return
+		}
+	}
+	env.ReflectSourceTargetForces(m.srcForceDamperK)
+}
+
+// drainSoilNutrients looks for the most fertile neighbour Soil and drains a nutrient unit from it with the {chance}.
+// Returns false if there is no fertile Soil nearby.
+func drainSoilNutrients(env types.TileEnvironment, chance float64) bool {
+	soilTiles, soilDirs := env.SearchNeighbours(
+		pkg.ValuePtr(false),
+		nil, false,
+		[]types.MaterialType{types.MaterialTypeSoil}, true,
+		nil, false,
+	)
+
+	bestIdx, bestNutrients := -1, 0
+	for i, tile := range soilTiles {
+		if nutrients := tile.Particle.GetStateParam(NutrientsParam); nutrients > bestNutrients {
+			bestIdx, bestNutrients = i, nutrients
+		}
+	}
+	if bestIdx < 0 {
+		return false
+	}
+
+	if pkg.RollChance(chance) {
+		env.UpdateNeighbourStateParam(soilDirs[bestIdx], NutrientsParam, bestNutrients-1)
+	}
+
+	return true
+}
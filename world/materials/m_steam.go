@@ -21,9 +21,12 @@ func NewSteam() Steam {
 			types.MaterialTypeSteam,
 			color.RGBA{R: 0x05, G: 0x00, B: 0xA7, A: 0xFF},
 			withFlags(types.MaterialFlagIsGas),
-			withCloseRangeType(types.MaterialCloseRangeTypeSelfOnly),
+			withCloseRangeType(types.MaterialCloseRangeTypeSurrounding),
 			withMass(5.0),
+			withLightTransmission(0.7),
 			withSelfHealthReduction(100.0, 0.25),
+			withThermal(0.2, 1.0),
+			withFlow(0.0, 0.3),
 		),
 	}
 }
@@ -46,6 +49,7 @@ func (m Steam) ProcessInternal(env types.TileEnvironment) {
 	m.commonProcessInternal(env)
 
 	env.AddReverseGravity()
+	env.MoveTileWithPressure()
 	env.DampSelfHealth(m.selfHealthDampStep)
 
 	if env.Health() < 10.0 && pkg.RollDice(3) {
@@ -57,7 +61,5 @@ func (m Steam) ProcessInternal(env types.TileEnvironment) {
 func (m Steam) ProcessCollision(env types.CollisionEnvironment) {
 	if env.IsFlagged(types.MaterialFlagIsGas) {
 		env.MoveSandSource()
-		return
 	}
-	env.SwapSourceTarget()
 }
@@ -0,0 +1,42 @@
+package materials
+
+import (
+	"image/color"
+
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+var _ types.Material = TNT{}
+
+// TNT is a heavy solid that explodes when heated (by Fire or another explosion).
+// The blast pushes, damages and destroys weak Particles in a wide range.
+type TNT struct {
+	base
+}
+
+func NewTNT() TNT {
+	return TNT{
+		base: newBase(
+			types.MaterialTypeTNT,
+			color.RGBA{R: 0xD0, G: 0x1C, B: 0x1F, A: 0xFF},
+			withCloseRangeType(types.MaterialCloseRangeTypeSelfOnly),
+			withMass(50.0),
+			withSourceDamping(0.9, 0.0),
+			withThermal(0.2, 2.0),
+			withExplosion(200.0, 8, 6.0, 900.0, 150.0),
+		),
+	}
+}
+
+func (m TNT) ProcessInternal(env types.TileEnvironment) {
+	if m.processExplosion(env) {
+		return
+	}
+	m.commonProcessInternal(env)
+
+	env.AddGravity()
+}
+
+func (m TNT) ProcessCollision(env types.CollisionEnvironment) {
+	env.ReflectSourceTargetForces(m.srcForceDamperK)
+}
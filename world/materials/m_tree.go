@@ -0,0 +1,229 @@
+package materials
+
+import (
+	"image/color"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+var _ types.Material = Tree{}
+
+const (
+	TreeRoleParam     = "tree_role"      // Tree part role (see TreeRole... values)
+	TreeGrowLeftParam = "tree_grow_left" // number of growth steps left for a trunk / branch end
+	TreeGrowDirParam  = "tree_grow_dir"  // branch growth side (pkg.Direction)
+	TreeStressParam   = "tree_stress"    // drought (root) / darkness (trunk top) counter
+	TreeFertileParam  = "tree_fertile"   // 1 if the trunk / branch has been sprouted by a root on a fertile Soil
+)
+
+const (
+	TreeRoleRoot    = iota // the lowest trunk Particle (takes Water)
+	TreeRoleSegment        // a grown trunk / branch part
+	TreeRoleCrown          // the growing trunk top
+	TreeRoleBranch         // the growing branch end
+)
+
+// Tree is a living wood growing from a Seed.
+// The root takes Water, the trunk top grows upward (sprouting branches) while there is enough light.
+// Grown trunk / branch ends sprout Leaves (in a warm season) and drop Seeds.
+// Tree dies into the Wood (deadwood) because of a drought or darkness, the death spreads over the whole Tree.
+// A root on a fertile Soil drains its nutrients: the Tree grows faster and doesn't suffer a drought.
+type Tree struct {
+	base
+	genome             types.TreeGenome
+	waterDrainStep     float64 // root surrounding Water drain
+	deathSpreadStep    float64 // dying part damage to the neighbouring Tree parts and Leaves
+	dormantTemperature float64 // max temperature of the dormancy (no growth)
+	leafBudTemperature float64 // min temperature to sprout Leaves
+	fertileGrowthK     float64 // growth chance multiplier for a Tree sprouted on a fertile Soil
+	nutrientsDrainK    float64 // root Soil nutrient unit drain chance per processing round
+}
+
+func NewTree() Tree {
+	return NewTreeWithGenome(types.DefaultTreeGenome())
+}
+
+// NewTreeWithGenome creates a new Tree Material with a custom TreeGenome.
+func NewTreeWithGenome(genome types.TreeGenome) Tree {
+	return Tree{
+		base: newBase(
+			types.MaterialTypeTree,
+			color.RGBA{R: 0x5C, G: 0x3A, B: 0x1E, A: 0xFF},
+			withFlags(types.MaterialFlagIsUnmovable, types.MaterialFlagIsFlammable),
+			withCloseRangeType(types.MaterialCloseRangeTypeSurrounding),
+			withMass(1000.0),
+			withSourceDamping(0.5, 0.0),
+			withThermal(0.1, 2.0),
+			withCollisionPair(types.MaterialTypeBug, 0.1, 0.3),
+			withPhaseTransition(types.MaterialPhaseChangeIgnite, 250.0, types.MaterialTypeFire),
+		),
+		genome:             genome,
+		waterDrainStep:     1.0,
+		deathSpreadStep:    1.0,
+		dormantTemperature: 5.0,
+		leafBudTemperature: 12.0,
+		fertileGrowthK:     2.0,
+		nutrientsDrainK:    0.01,
+	}
+}
+
+func (m Tree) ProcessInternal(env types.TileEnvironment) {
+	// A damaged part dies (a dying neighbour damages it)
+	if env.Health() < m.selfHealthInitial {
+		m.die(env)
+		return
+	}
+
+	switch role := env.StateParam(TreeRoleParam); role {
+	case TreeRoleRoot:
+		m.processRoot(env)
+	case TreeRoleCrown, TreeRoleBranch:
+		m.processEnd(env, role)
+	}
+}
+
+func (m Tree) ProcessCollision(env types.CollisionEnvironment) {
+	env.ReflectSourceTargetForces(m.srcForceDamperK)
+}
+
+// processRoot takes the surrounding Water (dies because of a drought) and sprouts the trunk.
+// A dormant root doesn't need Water.
+func (m Tree) processRoot(env types.TileEnvironment) {
+	if env.Temperature() <= m.dormantTemperature {
+		return
+	}
+
+	stress := env.StateParam(TreeStressParam) + 1
+	if env.DampNeighboursHealthByFlag(m.waterDrainStep, []types.MaterialType{types.MaterialTypeWater}, nil) > 0 {
+		stress = 0
+	}
+	isFertile := drainSoilNutrients(env, m.nutrientsDrainK)
+	if isFertile {
+		stress = 0
+	}
+	if stress >= m.genome.DroughtLimit {
+		m.die(env)
+		return
+	}
+	env.UpdateStateParam(TreeStressParam, stress)
+
+	if !pkg.RollChance(m.genome.GrowthChance) {
+		return
+	}
+
+	// Sprout (or regrow) the trunk
+	trunkTiles, _ := env.SearchNeighbours(
+		pkg.ValuePtr(false),
+		[]pkg.Direction{pkg.DirectionTop}, true,
+		[]types.MaterialType{types.MaterialTypeTree}, true,
+		nil, false,
+	)
+	if len(trunkTiles) == 0 {
+		fertile := 0
+		if isFertile {
+			fertile = 1
+		}
+		env.AddNewNeighbourTileWithState(m, []pkg.Direction{pkg.DirectionTop}, types.ParticleState{
+			TreeRoleParam:     TreeRoleCrown,
+			TreeGrowLeftParam: m.genome.TrunkHeight - 2,
+			TreeFertileParam:  fertile,
+		})
+	}
+}
+
+// processEnd grows the trunk top / branch end, a grown end sprouts Leaves and drops Seeds.
+// Trunk top dies because of the darkness.
+func (m Tree) processEnd(env types.TileEnvironment, role int) {
+	if role == TreeRoleCrown {
+		stress := env.StateParam(TreeStressParam)
+		if env.Light() < m.genome.MinLight {
+			stress++
+		} else if stress > 0 {
+			stress--
+		}
+		if stress >= m.genome.DarknessLimit {
+			m.die(env)
+			return
+		}
+		env.UpdateStateParam(TreeStressParam, stress)
+	}
+
+	growthChance, fertile := m.genome.GrowthChance, env.StateParam(TreeFertileParam)
+	if fertile == 1 {
+		growthChance *= m.fertileGrowthK
+	}
+
+	temp := env.Temperature()
+	if temp <= m.dormantTemperature || env.Light() < m.genome.MinLight || !pkg.RollChance(growthChance) {
+		return
+	}
+
+	// Grow
+	if growLeft := env.StateParam(TreeGrowLeftParam); growLeft > 0 {
+		growDir, sideDir := pkg.DirectionTop, pkg.Direction(env.StateParam(TreeGrowDirParam))
+		if role == TreeRoleBranch {
+			// Branch grows sideways or diagonally up
+			growDir = sideDir
+			if pkg.FlipCoin() {
+				growDir = sideDir.Rotate(1)
+				if sideDir == pkg.DirectionRight {
+					growDir = sideDir.Rotate(-1)
+				}
+			}
+		}
+
+		isGrown := env.AddNewNeighbourTileWithState(m, []pkg.Direction{growDir}, types.ParticleState{
+			TreeRoleParam:     role,
+			TreeGrowLeftParam: growLeft - 1,
+			TreeGrowDirParam:  sideDir.Int(),
+			TreeFertileParam:  fertile,
+		})
+		if !isGrown {
+			// Blocked: this is the end
+			env.UpdateStateParam(TreeGrowLeftParam, 0)
+			return
+		}
+		env.UpdateStateParam(TreeRoleParam, TreeRoleSegment)
+
+		if role == TreeRoleCrown && pkg.RollChance(m.genome.BranchChance) {
+			branchDir := pkg.DirectionLeft
+			if pkg.FlipCoin() {
+				branchDir = pkg.DirectionRight
+			}
+			env.AddNewNeighbourTileWithState(m, []pkg.Direction{branchDir}, types.ParticleState{
+				TreeRoleParam:     TreeRoleBranch,
+				TreeGrowLeftParam: m.genome.BranchLength - 1,
+				TreeGrowDirParam:  branchDir.Int(),
+				TreeFertileParam:  fertile,
+			})
+		}
+		return
+	}
+
+	// Sprout Leaves (canopy) and drop Seeds
+	if temp < m.leafBudTemperature {
+		return
+	}
+	leafTiles, _ := env.SearchNeighbours(
+		pkg.ValuePtr(false),
+		nil, false,
+		[]types.MaterialType{types.MaterialTypeLeaf}, true,
+		nil, false,
+	)
+	if len(leafTiles) == 0 {
+		env.AddNewNeighbourTileWithState(AllMaterialsSet[types.MaterialTypeLeaf], nil, types.ParticleState{
+			LeafAttachedParam: 1,
+			LeafSpreadParam:   m.genome.CanopyRadius,
+		})
+	}
+	if pkg.RollChance(m.genome.SeedChance) {
+		env.AddNewNeighbourTile(NewSeedWithGenome(m.genome), nil)
+	}
+}
+
+// die replaces the Tree part with the Wood (deadwood) damaging the neighbouring Tree parts and Leaves (the death spreads).
+func (m Tree) die(env types.TileEnvironment) {
+	env.DampNeighboursHealthByFlag(m.deathSpreadStep, []types.MaterialType{types.MaterialTypeTree, types.MaterialTypeLeaf}, nil)
+	env.ReplaceSelf(AllMaterialsSet[types.MaterialTypeWood])
+}
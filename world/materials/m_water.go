@@ -10,10 +10,13 @@ import (
 var _ types.Material = Water{}
 
 // Water spreads like water.
-// It puts out the Fire and makes the Grass grow faster.
+// It puts out the Fire, boils into Steam, freezes into Ice and makes the Grass grow faster.
+// Water slowly erodes the neighbour Rock into a (poor) Soil.
 type Water struct {
 	base
 	surroundingFireDamperStep float64 // surrounding fire damage
+	rockErosionChance         float64 // chance to erode a neighbour Rock per processing round
+	rockErodedNutrients       int     // eroded Rock Soil nutrients
 }
 
 func NewWater() Water {
@@ -24,10 +27,17 @@ func NewWater() Water {
 			withFlags(types.MaterialFlagIsLiquid),
 			withCloseRangeType(types.MaterialCloseRangeTypeSurrounding),
 			withMass(10.0),
+			withLightTransmission(0.8),
 			withSelfHealthReduction(100.0, 0.2),
 			withSourceDamping(0.2, 0.0),
+			withThermal(0.5, 4.0),
+			withFlow(0.0, 0.5),
+			withPhaseTransition(types.MaterialPhaseChangeBoil, 100.0, types.MaterialTypeSteam),
+			withPhaseTransition(types.MaterialPhaseChangeFreeze, 0.0, types.MaterialTypeIce),
 		),
 		surroundingFireDamperStep: 25.0,
+		rockErosionChance:         0.0002,
+		rockErodedNutrients:       10,
 	}
 }
 
@@ -56,14 +66,18 @@ func (m Water) ProcessInternal(env types.TileEnvironment) {
 	}
 
 	env.AddGravity()
+	env.MoveTileWithPressure()
 
-	if cnt := env.DampNeighboursHealthByFlag(m.surroundingFireDamperStep, nil, []types.MaterialFlag{types.MaterialFlagIsFire}); cnt > 0 {
-		env.DampSelfHealth(float64(cnt) * m.surroundingFireDamperStep)
-	}
+	// Put out the surrounding Fire (boiling is driven by the temperature)
+	env.DampNeighboursHealthByFlag(m.surroundingFireDamperStep, nil, []types.MaterialFlag{types.MaterialFlagIsFire})
 
-	if env.Health() <= 0.0 {
-		env.RemoveSelfHealthDamps()
-		env.ReplaceSelf(AllMaterialsSet[types.MaterialTypeSteam])
+	// Erode the neighbour Rock (rolled first, since Rock is rarely around)
+	if pkg.RollChance(m.rockErosionChance) {
+		env.ReplaceNeighbourTileByTypeWithState(
+			AllMaterialsSet[types.MaterialTypeSoil],
+			[]types.MaterialType{types.MaterialTypeRock},
+			types.ParticleState{NutrientsParam: m.rockErodedNutrients},
+		)
 	}
 }
 
@@ -73,6 +87,5 @@ func (m Water) ProcessCollision(env types.CollisionEnvironment) {
 			return
 		}
 	}
-	env.SwapSourceTarget()
 	env.DampSourceForce(m.srcForceDamperK)
 }
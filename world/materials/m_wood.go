@@ -23,6 +23,9 @@ func NewWood() Wood {
 			withMass(1000.0),
 			withSelfHealthReduction(100.0, 0.5),
 			withSourceDamping(0.5, 0.0),
+			withThermal(0.1, 2.0),
+			withCollisionPair(types.MaterialTypeBug, 0.1, 0.3),
+			withPhaseTransition(types.MaterialPhaseChangeIgnite, 250.0, types.MaterialTypeFire),
 		),
 	}
 }
@@ -9,51 +9,86 @@ import (
 	"github.com/itiky/goPixelWorld/world/types"
 )
 
-const (
-	natureCloudsTimeout     = 60
-	natureWindChangeTimeout = 500
-)
-
 func (m *Map) initNatureEvents() {
-	m.natureCloudsTimeout = natureCloudsTimeout
-	m.natureWindChangeTimeout = natureWindChangeTimeout
+	m.natureCloudsTimeout = m.natureConfig.CloudsPeriod
+	m.natureWindChangeTimeout = m.natureConfig.WindChangePeriod
+	// Start with the morning
+	m.dayTime = m.natureConfig.DayLength / 8
+	m.daylight, m.daylightExported = 1.0, 1.0
+	m.seedNatureRand()
+	m.initSeason()
+}
+
+// seedNatureRand (re)creates the random events generator using the config seed (a random one if not set).
+func (m *Map) seedNatureRand() {
+	seed := m.natureConfig.Seed
+	if seed == 0 {
+		seed = rand.Int63()
+	}
+	m.natureRand = rand.New(rand.NewSource(seed))
+}
+
+// setNatureConfig switches the nature effects parameters at runtime (pending timeouts are shortened if needed).
+func (m *Map) setNatureConfig(config types.NatureConfig) {
+	reseed := config.Seed != 0 && config.Seed != m.natureConfig.Seed
+	reseason := config.SeasonLength != m.natureConfig.SeasonLength || config.StartSeason != m.natureConfig.StartSeason
+	m.natureConfig = config
+	if reseed {
+		m.seedNatureRand()
+	}
+	if reseason {
+		m.initSeason()
+	}
+	if m.natureCloudsTimeout > config.CloudsPeriod {
+		m.natureCloudsTimeout = config.CloudsPeriod
+	}
+	if m.natureWindChangeTimeout > config.WindChangePeriod {
+		m.natureWindChangeTimeout = config.WindChangePeriod
+	}
 }
 
 func (m *Map) handleNatureEvents() (inputActions []types.InputAction) {
+	cfg := m.natureConfig
+
 	m.natureCloudsTimeout--
 	m.natureWindChangeTimeout--
+	m.updateSeason()
+
+	if m.natureCloudsTimeout <= 0 {
+		// Clouds drop the Snow in the frost
+		cloudsMaterial := materials.AllMaterialsSet[types.MaterialTypeSteam]
+		if m.ambientTemp <= seasonSnowTemperature {
+			cloudsMaterial = materials.AllMaterialsSet[types.MaterialTypeSnow]
+		}
 
-	if m.natureCloudsTimeout == 0 {
 		for x := 1; x < m.width-1; x++ {
-			for y := m.height / 8; y > 1; y-- {
-				if !pkg.RollDice(1500) {
+			for y := int(float64(m.height) * cfg.CloudsHeight); y > 1; y-- {
+				if !pkg.RollChance(cfg.CloudsChance) {
 					continue
 				}
 
 				inputActions = append(inputActions, types.CreateParticlesInputAction{
 					X:        x,
 					Y:        y,
-					Material: materials.AllMaterialsSet[types.MaterialTypeSteam],
+					Material: cloudsMaterial,
 				})
 			}
 		}
-		m.natureCloudsTimeout = natureCloudsTimeout
+		m.natureCloudsTimeout = cfg.CloudsPeriod
 	}
-	if m.natureWindChangeTimeout == 0 {
-		genWindMag := func() float64 {
-			return rand.Float64() / 10.0
-		}
-
-		switch rand.Int31n(5) {
-		case 0:
-			closerange.SetWind(genWindMag(), true)
-		case 1:
-			closerange.SetWind(genWindMag(), false)
-		default:
+	if m.natureWindChangeTimeout <= 0 {
+		if pkg.RollChance(cfg.WindChance) {
+			closerange.SetWind(rand.Float64()*cfg.WindMagMax, pkg.FlipCoin())
+		} else {
 			closerange.SetWind(0.0, true)
 		}
 
-		m.natureWindChangeTimeout = natureWindChangeTimeout
+		m.natureWindChangeTimeout = cfg.WindChangePeriod
+	}
+	if cfg.LightningChance > 0.0 && m.natureRand.Float64() < cfg.LightningChance {
+		inputActions = append(inputActions, types.StrikeLightningInputAction{
+			X: 1 + m.natureRand.Intn(m.width-2),
+		})
 	}
 
 	return inputActions
@@ -0,0 +1,52 @@
+package world
+
+import (
+	"image/color"
+
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+// prepareOverlayOutput fills up the overlay output buffer based on the current overlay type.
+func (m *Map) prepareOverlayOutput() {
+	pixelIdx := 0
+	defer func() {
+		if pixelIdx < len(m.procOverlayOutput) {
+			m.procOverlayOutput[pixelIdx].Ready = false
+		}
+	}()
+
+	if m.overlayType == types.OverlayTypeNone {
+		return
+	}
+	if m.overlayType == types.OverlayTypeWind {
+		pixelIdx = m.appendWindOverlay(pixelIdx)
+		return
+	}
+
+	for x := 0; x < m.width; x++ {
+		for y := 0; y < m.height; y++ {
+			var pixelColor color.Color
+			var ok bool
+
+			switch m.overlayType {
+			case types.OverlayTypeTemperature:
+				pixelColor, ok = heatOverlayColor(m.heat.Get(x, y))
+			case types.OverlayTypePressure:
+				pixelColor, ok = pressureOverlayColor(m.pressure.Get(x, y))
+			case types.OverlayTypeLight:
+				pixelColor, ok = lightOverlayColor(m.light.Get(x, y))
+			case types.OverlayTypePheromone:
+				pixelColor, ok = pheromoneOverlayColor(m.pheromones[types.PheromoneTypeFood].Get(x, y), m.pheromones[types.PheromoneTypeHome].Get(x, y))
+			}
+			if !ok {
+				continue
+			}
+
+			m.procOverlayOutput[pixelIdx].Ready = true
+			m.procOverlayOutput[pixelIdx].PosX = x
+			m.procOverlayOutput[pixelIdx].PosY = y
+			m.procOverlayOutput[pixelIdx].ParticleColor = pixelColor
+			pixelIdx++
+		}
+	}
+}
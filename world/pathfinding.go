@@ -0,0 +1,209 @@
+package world
+
+import (
+	"sync"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+const (
+	// pathRegionSize defines the pathfinding cache region size [Tiles].
+	pathRegionSize = 16
+	// pathSearchRange defines the max distance from the region to a target [Tiles].
+	pathSearchRange = 48
+	// pathCacheRounds defines the number of processing rounds a region distance field is reused for.
+	pathCacheRounds = 10
+)
+
+type (
+	// pathFinder builds and caches flow fields to the nearest target Material Tile.
+	// A flow field is built per region (and per target / path mode) on demand: it keeps the path distance to the nearest target
+	// for all Tiles within the region extended by the search range.
+	// Fields are built concurrently by Tile workers while the grid is read-only.
+	pathFinder struct {
+		sync.Mutex
+		regions map[pathRegionKey]*pathRegion
+	}
+
+	// pathRegionKey defines the cached region flow field key.
+	pathRegionKey struct {
+		regionX, regionY int
+		targetType       types.MaterialType
+		mode             types.PathMode
+	}
+
+	// pathRegion keeps the region flow field (the distance to the nearest target, -1 if unreachable).
+	pathRegion struct {
+		sync.Mutex
+		builtRound    int     // processing round the field was built at (-1 if not built yet)
+		xFrom, yFrom  int     // field box top-left Position
+		width, height int     // field box size
+		dist          []int16 // per Tile distances [x*height+y]
+		queue         []types.Position
+	}
+)
+
+// newPathFinder creates a new empty pathFinder.
+func newPathFinder() *pathFinder {
+	return &pathFinder{
+		regions: make(map[pathRegionKey]*pathRegion),
+	}
+}
+
+// findPath returns the next step Direction from the Position towards the nearest Tile of the {targetType} Material.
+// Returns the path length and false if there is no reachable target within the search range.
+// Safe for the concurrent use while Tiles are processed.
+func (m *Map) findPath(from types.Position, targetType types.MaterialType, mode types.PathMode) (pkg.Direction, int, bool) {
+	if !m.isPositionValid(from.X, from.Y) {
+		return pkg.DirectionNone, 0, false
+	}
+
+	key := pathRegionKey{
+		regionX:    from.X / pathRegionSize,
+		regionY:    from.Y / pathRegionSize,
+		targetType: targetType,
+		mode:       mode,
+	}
+
+	m.pathFinder.Lock()
+	region, found := m.pathFinder.regions[key]
+	if !found {
+		region = &pathRegion{builtRound: -1}
+		m.pathFinder.regions[key] = region
+	}
+	m.pathFinder.Unlock()
+
+	region.Lock()
+	defer region.Unlock()
+
+	if region.builtRound < 0 || m.round-region.builtRound >= pathCacheRounds {
+		m.buildPathRegion(region, key)
+		region.builtRound = m.round
+	}
+
+	// Step to the neighbour closest to the target
+	bestDir, bestDist := pkg.DirectionNone, -1
+	for _, dir := range pkg.AllDirections {
+		dx, dy := dir.Offset()
+		dist := region.distAt(from.X+dx, from.Y+dy)
+		if dist < 0 {
+			continue
+		}
+		if bestDist < 0 || dist < bestDist {
+			bestDir, bestDist = dir, dist
+		}
+	}
+	if bestDist < 0 {
+		return pkg.DirectionNone, 0, false
+	}
+
+	return bestDir, bestDist + 1, true
+}
+
+// buildPathRegion builds the region flow field: a multi-source BFS from target Tiles over passable ones.
+func (m *Map) buildPathRegion(region *pathRegion, key pathRegionKey) {
+	xFrom, yFrom := key.regionX*pathRegionSize-pathSearchRange, key.regionY*pathRegionSize-pathSearchRange
+	xTo, yTo := (key.regionX+1)*pathRegionSize+pathSearchRange, (key.regionY+1)*pathRegionSize+pathSearchRange
+	xFrom, yFrom = clampInt(xFrom, 0, m.width), clampInt(yFrom, 0, m.height)
+	xTo, yTo = clampInt(xTo, 0, m.width), clampInt(yTo, 0, m.height)
+
+	region.xFrom, region.yFrom = xFrom, yFrom
+	region.width, region.height = xTo-xFrom, yTo-yFrom
+	if size := region.width * region.height; cap(region.dist) < size {
+		region.dist = make([]int16, size)
+	} else {
+		region.dist = region.dist[:size]
+	}
+	for i := range region.dist {
+		region.dist[i] = -1
+	}
+
+	// Sources
+	region.queue = region.queue[:0]
+	for x := xFrom; x < xTo; x++ {
+		for y := yFrom; y < yTo; y++ {
+			if tile := m.getTile(x, y); tile.HasParticle() && tile.Particle.Material().Type() == key.targetType {
+				region.setDist(x, y, 0)
+				region.queue = append(region.queue, tile.Pos)
+			}
+		}
+	}
+
+	// BFS
+	for i := 0; i < len(region.queue); i++ {
+		pos := region.queue[i]
+		dist := region.distAt(pos.X, pos.Y)
+		if dist >= pathSearchRange {
+			continue
+		}
+
+		for _, dir := range pkg.AllDirections {
+			dx, dy := dir.Offset()
+			x, y := pos.X+dx, pos.Y+dy
+			if !region.contains(x, y) || region.distAt(x, y) >= 0 || !m.isPathPassable(x, y, key.mode) {
+				continue
+			}
+
+			region.setDist(x, y, dist+1)
+			region.queue = append(region.queue, types.Position{X: x, Y: y})
+		}
+	}
+}
+
+// isPathPassable checks if an agent can travel through the Tile.
+func (m *Map) isPathPassable(x, y int, mode types.PathMode) bool {
+	tile := m.getTile(x, y)
+
+	switch mode {
+	case types.PathModeSwim:
+		return tile.HasParticle() && tile.Particle.Material().IsFlagged(types.MaterialFlagIsLiquid)
+	case types.PathModeFly:
+		return !tile.HasParticle()
+	}
+
+	// Walk: an empty Tile next to a solid support (in the local gravity direction sector)
+	if tile.HasParticle() {
+		return false
+	}
+
+	downDir := m.gravity.direction(x, y)
+	if downDir == pkg.DirectionNone {
+		return true
+	}
+	for _, dir := range downDir.Sector(1) {
+		dx, dy := dir.Offset()
+		if !m.isPositionValid(x+dx, y+dy) {
+			continue
+		}
+
+		support := m.getTile(x+dx, y+dy)
+		if !support.HasParticle() {
+			continue
+		}
+		if material := support.Particle.Material(); !material.IsFlagged(types.MaterialFlagIsLiquid) && !material.IsFlagged(types.MaterialFlagIsGas) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// contains checks if the Position is within the region field box.
+func (r *pathRegion) contains(x, y int) bool {
+	return x >= r.xFrom && y >= r.yFrom && x < r.xFrom+r.width && y < r.yFrom+r.height
+}
+
+// distAt returns the distance to the nearest target (-1 if unreachable or out of the field box).
+func (r *pathRegion) distAt(x, y int) int {
+	if !r.contains(x, y) {
+		return -1
+	}
+
+	return int(r.dist[(x-r.xFrom)*r.height+(y-r.yFrom)])
+}
+
+// setDist sets the distance to the nearest target.
+func (r *pathRegion) setDist(x, y, dist int) {
+	r.dist[(x-r.xFrom)*r.height+(y-r.yFrom)] = int16(dist)
+}
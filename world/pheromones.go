@@ -0,0 +1,108 @@
+package world
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+const (
+	// pheromoneDiffusionRate defines the per round pheromone spread rate between cross neighbours.
+	pheromoneDiffusionRate = 0.1
+	// pheromoneEvaporationRate defines the per round pheromone level reduction rate.
+	pheromoneEvaporationRate = 0.004
+	// pheromoneMinLevel defines the min pheromone level to track (weaker scent is evaporated completely).
+	pheromoneMinLevel = 0.01
+	// pheromoneMaxLevel defines the max pheromone level per Tile.
+	pheromoneMaxLevel = 10.0
+)
+
+// processPheromones calculates the next pheromone fields state for the grid stripe [xFrom, xTo).
+// Each pheromone diffuses to cross neighbours and evaporates, unmovable Particles (walls) block the scent.
+func (m *Map) processPheromones(xFrom, xTo int) {
+	isBlocked := func(x, y int) bool {
+		tile := m.getTile(x, y)
+		return tile.HasParticle() && tile.Particle.Material().IsFlagged(types.MaterialFlagIsUnmovable)
+	}
+
+	for _, field := range m.pheromones {
+		for x := xFrom; x < xTo; x++ {
+			for y := 0; y < m.height; y++ {
+				if isBlocked(x, y) {
+					field.SetNext(x, y, 0.0)
+					continue
+				}
+
+				level := field.Get(x, y)
+
+				// Diffusion
+				levelDelta := 0.0
+				spread := func(dx, dy int) {
+					nx, ny := x+dx, y+dy
+					if !m.isPositionValid(nx, ny) || isBlocked(nx, ny) {
+						return
+					}
+					levelDelta += field.Get(nx, ny) - level
+				}
+				spread(0, -1)
+				spread(1, 0)
+				spread(0, 1)
+				spread(-1, 0)
+				level += pheromoneDiffusionRate * levelDelta
+
+				// Evaporation
+				level *= 1.0 - pheromoneEvaporationRate
+				if level < pheromoneMinLevel {
+					level = 0.0
+				}
+
+				field.SetNext(x, y, level)
+			}
+		}
+	}
+}
+
+// depositPheromone adds the pheromone amount to the Tile (limited by the pheromoneMaxLevel).
+func (m *Map) depositPheromone(pos types.Position, pType types.PheromoneType, amount float64) {
+	if !m.isPositionValid(pos.X, pos.Y) || pType < 0 || pType >= types.PheromoneTypesNum {
+		return
+	}
+
+	field := m.pheromones[pType]
+	field.Set(pos.X, pos.Y, math.Min(field.Get(pos.X, pos.Y)+amount, pheromoneMaxLevel))
+}
+
+// pheromoneAt returns the current pheromone level at the Position (0.0 if the Position is out of the grid).
+func (m *Map) pheromoneAt(pType types.PheromoneType, x, y int) float64 {
+	if !m.isPositionValid(x, y) || pType < 0 || pType >= types.PheromoneTypesNum {
+		return 0.0
+	}
+
+	return m.pheromones[pType].Get(x, y)
+}
+
+// pheromoneOverlayColor returns the pheromone overlay color (food trails are green, home trails are purple).
+// Returns false if there is no scent on the Tile.
+func pheromoneOverlayColor(food, home float64) (color.NRGBA, bool) {
+	const (
+		levelRange  = 3.0 // level that gets the max color intensity
+		colorLevels = 8   // color quantization levels (reduces the number of unique colors)
+	)
+
+	if food < pheromoneMinLevel && home < pheromoneMinLevel {
+		return color.NRGBA{}, false
+	}
+
+	quantize := func(level float64) float64 {
+		return math.Ceil(math.Min(level/levelRange, 1.0)*colorLevels) / colorLevels
+	}
+	foodLevel, homeLevel := quantize(food), quantize(home)
+
+	return color.NRGBA{
+		R: uint8(homeLevel * 0xC0),
+		G: uint8(foodLevel * 0xFF),
+		B: uint8(homeLevel * 0xFF),
+		A: uint8(math.Max(foodLevel, homeLevel) * 0xC0),
+	}, true
+}
@@ -0,0 +1,43 @@
+package world
+
+import (
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+const (
+	// populationSamplePeriod defines the number of processing rounds between population snapshots.
+	populationSamplePeriod = 30
+	// populationHistoryMax defines the max number of population snapshots kept.
+	populationHistoryMax = 300
+)
+
+// updatePopulation takes a population snapshot (species numbers, Bug trait distributions) every populationSamplePeriod rounds.
+// Called within the state export, when the processing is halted.
+func (m *Map) updatePopulation() {
+	m.round++
+	if m.round%populationSamplePeriod != 0 {
+		return
+	}
+
+	m.bugGenomesBuf = m.bugGenomesBuf[:0]
+	predatorsCnt := 0
+	for _, tile := range m.particles {
+		switch tile.Particle.Material().Type() {
+		case types.MaterialTypeBug:
+			m.bugGenomesBuf = append(m.bugGenomesBuf, types.NewBugGenomeFromState(tile.Particle.GetStateParam))
+		case types.MaterialTypePredator:
+			predatorsCnt++
+		}
+	}
+
+	if len(m.population) >= populationHistoryMax {
+		copy(m.population, m.population[1:])
+		m.population = m.population[:len(m.population)-1]
+	}
+	m.population = append(m.population, types.NewPopulationStats(m.round, m.bugGenomesBuf, predatorsCnt))
+}
+
+// Population returns the population snapshots history (the oldest first).
+func (m *Map) Population() []types.PopulationStats {
+	return m.population
+}
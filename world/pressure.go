@@ -0,0 +1,197 @@
+package world
+
+import (
+	"image/color"
+	"math"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+// liquidSupport defines a liquid Tile support state (pressure calculation buffer).
+type liquidSupport int8
+
+const (
+	liquidSupportUnknown liquidSupport = iota
+	liquidSupportYes
+	liquidSupportNo
+)
+
+// processPressure calculates the next pressure field state for the whole grid.
+// Liquid pressure is the depth below the highest free surface of the connected (same Material) liquid body
+// (the height is measured against the local gravity, so that works for any gravity direction and around "planets"),
+// so a liquid in communicating vessels is pressurized on the lower surface side and rises until levels are equal.
+// Only a supported liquid Tile (see isLiquidSupported) is pressurized or counts as a free surface,
+// so a falling stream or a hanging column doesn't spread sideways in the air.
+// Gas pressure is the number of gas neighbours (gas expands into empty spaces).
+// Non-fluid and empty Tiles have zero pressure.
+func (m *Map) processPressure() {
+	// Reset the state
+	for x := 0; x < m.width; x++ {
+		for y := 0; y < m.height; y++ {
+			m.pressureLabels[x][y] = 0
+			m.pressureSupport[x][y] = liquidSupportUnknown
+			m.pressure.SetNext(x, y, 0.0)
+		}
+	}
+
+	isFluid := func(tile *types.Tile, flag types.MaterialFlag) bool {
+		return tile.HasParticle() && tile.Particle.Material().IsFlagged(flag)
+	}
+
+	// Liquid bodies
+	label := 0
+	var bodyTiles, bodyStack []*types.Tile
+	for x := 0; x < m.width; x++ {
+		for y := 0; y < m.height; y++ {
+			startTile := m.getTile(x, y)
+			if m.pressureLabels[x][y] != 0 || !isFluid(startTile, types.MaterialFlagIsLiquid) {
+				continue
+			}
+			label++
+			mType := startTile.Particle.Material().Type()
+
+			// Flood fill the body (4-connectivity)
+			bodyTiles, bodyStack = bodyTiles[:0], append(bodyStack[:0], startTile)
+			m.pressureLabels[x][y] = label
+			for len(bodyStack) > 0 {
+				tile := bodyStack[len(bodyStack)-1]
+				bodyStack = bodyStack[:len(bodyStack)-1]
+				bodyTiles = append(bodyTiles, tile)
+
+				tx, ty := tile.Pos.X, tile.Pos.Y
+				for _, d := range [4][2]int{{0, -1}, {1, 0}, {0, 1}, {-1, 0}} {
+					nx, ny := tx+d[0], ty+d[1]
+					if !m.isPositionValid(nx, ny) || m.pressureLabels[nx][ny] != 0 {
+						continue
+					}
+
+					nTile := m.getTile(nx, ny)
+					if !nTile.HasParticle() || nTile.Particle.Material().Type() != mType {
+						continue
+					}
+					m.pressureLabels[nx][ny] = label
+					bodyStack = append(bodyStack, nTile)
+				}
+			}
+
+			// Search for the highest free surface
+			surfaceH, topH := -math.MaxFloat64, -math.MaxFloat64
+			for _, tile := range bodyTiles {
+				tx, ty := tile.Pos.X, tile.Pos.Y
+				if !m.isLiquidSupported(tx, ty) {
+					continue
+				}
+
+				h := m.gravity.height(tx, ty)
+				if h > topH {
+					topH = h
+				}
+				if h > surfaceH {
+					upDx, upDy := m.gravity.direction(tx, ty).Rotate180().Offset()
+					if ux, uy := tx+upDx, ty+upDy; m.isPositionValid(ux, uy) && !m.getTile(ux, uy).HasParticle() {
+						surfaceH = h
+					}
+				}
+			}
+
+			// Closed vessel (no free surface)
+			if surfaceH == -math.MaxFloat64 {
+				surfaceH = topH
+			}
+			for _, tile := range bodyTiles {
+				if !m.isLiquidSupported(tile.Pos.X, tile.Pos.Y) {
+					continue
+				}
+				m.pressure.SetNext(tile.Pos.X, tile.Pos.Y, surfaceH-m.gravity.height(tile.Pos.X, tile.Pos.Y))
+			}
+		}
+	}
+
+	// Gases
+	for x := 0; x < m.width; x++ {
+		for y := 0; y < m.height; y++ {
+			if !isFluid(m.getTile(x, y), types.MaterialFlagIsGas) {
+				continue
+			}
+
+			gasNeighboursCnt := 0
+			for dx := -1; dx <= 1; dx++ {
+				for dy := -1; dy <= 1; dy++ {
+					nx, ny := x+dx, y+dy
+					if (dx == 0 && dy == 0) || !m.isPositionValid(nx, ny) {
+						continue
+					}
+					if isFluid(m.getTile(nx, ny), types.MaterialFlagIsGas) {
+						gasNeighboursCnt++
+					}
+				}
+			}
+			m.pressure.SetNext(x, y, float64(gasNeighboursCnt))
+		}
+	}
+}
+
+// isLiquidSupported checks if the labeled liquid Tile is supported in the local gravity direction:
+// the column below it (within the same liquid body) rests on a non-empty Tile.
+// Results are cached for the whole column walked down.
+func (m *Map) isLiquidSupported(x, y int) bool {
+	label := m.pressureLabels[x][y]
+	state := liquidSupportYes
+
+	var pathLen int
+	cx, cy := x, y
+	for ; pathLen <= m.width+m.height; pathLen++ {
+		if cached := m.pressureSupport[cx][cy]; cached != liquidSupportUnknown {
+			state = cached
+			break
+		}
+
+		downDir := m.gravity.direction(cx, cy)
+		if downDir == pkg.DirectionNone {
+			break
+		}
+		downDx, downDy := downDir.Offset()
+		nx, ny := cx+downDx, cy+downDy
+		if !m.isPositionValid(nx, ny) {
+			break
+		}
+		if !m.getTile(nx, ny).HasParticle() {
+			state = liquidSupportNo
+			break
+		}
+		if m.pressureLabels[nx][ny] != label {
+			break
+		}
+		cx, cy = nx, ny
+	}
+
+	// Cache the walked column
+	cx, cy = x, y
+	for i := 0; i < pathLen; i++ {
+		m.pressureSupport[cx][cy] = state
+		downDx, downDy := m.gravity.direction(cx, cy).Offset()
+		cx, cy = cx+downDx, cy+downDy
+	}
+	m.pressureSupport[cx][cy] = state
+
+	return state == liquidSupportYes
+}
+
+// pressureOverlayColor returns the pressure overlay color.
+// Returns false if there is no pressure.
+func pressureOverlayColor(pressure float64) (color.NRGBA, bool) {
+	const (
+		pressureRange = 30.0 // pressure that gets the max alpha
+		alphaLevels   = 16   // alpha quantization levels (reduces the number of unique colors)
+	)
+
+	if pressure <= 0.0 {
+		return color.NRGBA{}, false
+	}
+
+	level := math.Min(pressure/pressureRange, 1.0)
+	level = math.Ceil(level*alphaLevels) / alphaLevels
+
+	return color.NRGBA{R: 0xB0, G: 0x00, B: 0xFF, A: uint8(level * 0xC0)}, true
+}
@@ -9,6 +9,14 @@ const (
 	tileWorkersNum = 8
 	// procTileJobChSize defines the procTileJobCh buffer size.
 	procTileJobChSize = 50000
+	// fieldWorkersNum defines the number of field processing workers.
+	fieldWorkersNum = 4
+	// fieldStripesNum defines the number of grid stripes fields are processed by (a field worker job).
+	fieldStripesNum = 8
+	// fieldJobGlobalIdx defines the field worker job index for fields that require the whole grid.
+	fieldJobGlobalIdx = -1
+	// maxCollisionBounces defines the max number of collisions a Particle can have during a single movement.
+	maxCollisionBounces = 3
 )
 
 // initProcessing inits the processing engine.
@@ -24,6 +32,15 @@ func (m *Map) initProcessing() {
 		go m.tileWorker(i)
 	}
 
+	// Start field workers and init output queue for each grid stripe
+	m.procFieldJobCh = make(chan int, fieldStripesNum+1)
+	for i := 0; i < fieldStripesNum; i++ {
+		m.procFieldActions = append(m.procFieldActions, make([]types.Action, 0, procTileJobChSize))
+	}
+	for i := 0; i < fieldWorkersNum; i++ {
+		go m.fieldWorker()
+	}
+
 	// Start the main process ahead worker
 	go m.processingWorker()
 	m.processingStart()
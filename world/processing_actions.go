@@ -4,7 +4,7 @@ import (
 	"github.com/itiky/goPixelWorld/world/types"
 )
 
-// processActions iterates over each Tile worker output queue and applies pushed Actions modifying the Map state.
+// processActions iterates over each Tile / field worker output queue and applies pushed Actions modifying the Map state.
 // Each Action "doesn't know" about its predecessors, so the apply operation must be idempotent.
 func (m *Map) processActions() {
 	if m.monitor != nil {
@@ -27,88 +27,136 @@ func (m *Map) processActions() {
 		return tile
 	}
 
-	for _, workerActions := range m.procActions {
-		for _, aBz := range workerActions {
-			switch a := aBz.(type) {
-			case *types.MultiplyForce:
-				tile := getExistingTile(a.TilePos, a.ParticleID)
-				if tile == nil {
-					break
-				}
-				tile.Particle.MultiplyForce(a.K)
-			case *types.ReflectForce:
-				tile := getExistingTile(a.TilePos, a.ParticleID)
-				if tile == nil {
-					break
-				}
-				tile.Particle.ReflectForce(a.Horizontal, a.Vertical)
-			case *types.AddForce:
-				tile := getExistingTile(a.TilePos, a.ParticleID)
-				if tile == nil {
-					break
-				}
-				tile.Particle.AddForce(a.ForceVec)
-			case *types.AlterForce:
-				tile := getExistingTile(a.TilePos, a.ParticleID)
-				if tile == nil {
-					break
-				}
-				tile.Particle.SetForce(a.NewForceVec)
-			case *types.RotateForce:
-				tile := getExistingTile(a.TilePos, a.ParticleID)
-				if tile == nil {
-					break
-				}
-				tile.Particle.RotateForce(a.Angle)
-			case *types.MoveTile:
-				tile1 := getExistingTile(a.TilePos, a.ParticleID)
-				if tile1 == nil {
-					break
-				}
-				tile2 := getEmptyTile(a.NewTilePos)
-				if tile2 == nil {
-					break
-				}
-				m.moveTile(tile1, a.NewTilePos)
-			case *types.SwapTiles:
-				tile1 := getExistingTile(a.TilePos, a.ParticleID)
-				if tile1 == nil {
-					break
-				}
-				tile2 := getExistingTile(a.SwapTilePos, a.SwapParticleID)
-				if tile2 == nil {
-					break
-				}
-				m.swapTiles(tile1, tile2)
-			case *types.ReduceHealth:
-				tile := getExistingTile(a.TilePos, a.ParticleID)
-				if tile == nil {
-					break
-				}
-				tile.Particle.ReduceHealth(a.HealthDelta)
-				if tile.Particle.IsDestroyed() {
+	// Input Actions go first: input Particles haven't moved since they were created
+	for _, queues := range [][][]types.Action{{m.inputTileActions}, m.procActions, m.procFieldActions} {
+		for _, workerActions := range queues {
+			for _, aBz := range workerActions {
+				switch a := aBz.(type) {
+				case *types.MultiplyForce:
+					tile := getExistingTile(a.TilePos, a.ParticleID)
+					if tile == nil {
+						break
+					}
+					tile.Particle.MultiplyForce(a.K)
+				case *types.ReflectForce:
+					tile := getExistingTile(a.TilePos, a.ParticleID)
+					if tile == nil {
+						break
+					}
+					tile.Particle.ReflectForce(a.Horizontal, a.Vertical)
+				case *types.AddForce:
+					tile := getExistingTile(a.TilePos, a.ParticleID)
+					if tile == nil {
+						break
+					}
+					tile.Particle.AddForce(a.ForceVec)
+				case *types.AlterForce:
+					tile := getExistingTile(a.TilePos, a.ParticleID)
+					if tile == nil {
+						break
+					}
+					tile.Particle.SetForce(a.NewForceVec)
+				case *types.RotateForce:
+					tile := getExistingTile(a.TilePos, a.ParticleID)
+					if tile == nil {
+						break
+					}
+					tile.Particle.RotateForce(a.Angle)
+				case *types.MoveTile:
+					tile1 := getExistingTile(a.TilePos, a.ParticleID)
+					if tile1 == nil {
+						break
+					}
+					tile2 := getEmptyTile(a.NewTilePos)
+					if tile2 == nil {
+						break
+					}
+					m.moveTile(tile1, a.NewTilePos)
+				case *types.SwapTiles:
+					tile1 := getExistingTile(a.TilePos, a.ParticleID)
+					if tile1 == nil {
+						break
+					}
+					tile2 := getExistingTile(a.SwapTilePos, a.SwapParticleID)
+					if tile2 == nil {
+						break
+					}
+					m.swapTiles(tile1, tile2)
+				case *types.ReduceHealth:
+					tile := getExistingTile(a.TilePos, a.ParticleID)
+					if tile == nil {
+						break
+					}
+					tile.Particle.ReduceHealth(a.HealthDelta)
+					if tile.Particle.IsDestroyed() {
+						m.removeParticle(tile)
+					}
+				case *types.TileReplace:
+					tile := getExistingTile(a.TilePos, a.ParticleID)
+					if tile == nil {
+						break
+					}
 					m.removeParticle(tile)
+					m.createParticle(tile, a.Material)
+					for key, value := range a.State {
+						tile.Particle.SetStateParam(key, value)
+					}
+				case *types.UpdateStateParam:
+					tile := getExistingTile(a.TilePos, a.ParticleID)
+					if tile == nil {
+						break
+					}
+					tile.Particle.SetStateParam(a.ParamKey, a.ParamValue)
+				case *types.BondCreate:
+					tile1 := getExistingTile(a.TilePos, a.ParticleID)
+					if tile1 == nil {
+						break
+					}
+					tile2 := getExistingTile(a.BondTilePos, a.BondParticleID)
+					if tile2 == nil {
+						break
+					}
+					m.createBond(tile1, tile2, a.Bond)
+				case *types.BondRemove:
+					tile := getExistingTile(a.TilePos, a.ParticleID)
+					if tile == nil {
+						break
+					}
+					m.removeBond(tile.Particle, a.BondParticleID)
+				case *types.Explode:
+					tile := getExistingTile(a.TilePos, a.ParticleID)
+					if tile == nil {
+						break
+					}
+					m.explode(tile, a)
+				case *types.Consume:
+					tile := getExistingTile(a.TilePos, a.ParticleID)
+					if tile == nil {
+						break
+					}
+					preyTile := getExistingTile(a.PreyTilePos, a.PreyParticleID)
+					if preyTile == nil {
+						break
+					}
+					healthGain := preyTile.Particle.Health() * a.AbsorbK
+					if m.removeParticle(preyTile) {
+						tile.Particle.ReduceHealth(-healthGain)
+					}
+				case *types.DepositPheromone:
+					m.depositPheromone(a.TilePos, a.PheromoneType, a.Amount)
+				case *types.TileAdd:
+					tile := getEmptyTile(a.TilePos)
+					if tile == nil {
+						break
+					}
+					m.createParticle(tile, a.Material)
+					for key, value := range a.State {
+						tile.Particle.SetStateParam(key, value)
+					}
 				}
-			case *types.TileReplace:
-				tile := getExistingTile(a.TilePos, a.ParticleID)
-				if tile == nil {
-					break
-				}
-				m.removeParticle(tile)
-				m.createParticle(tile, a.Material)
-			case *types.UpdateStateParam:
-				tile := getExistingTile(a.TilePos, a.ParticleID)
-				if tile == nil {
-					break
-				}
-				tile.Particle.SetStateParam(a.ParamKey, a.ParamValue)
-			case *types.TileAdd:
-				tile := getEmptyTile(a.TilePos)
-				if tile == nil {
-					break
-				}
-				m.createParticle(tile, a.Material)
 			}
 		}
 	}
+
+	m.inputTileActions = m.inputTileActions[:0]
 }
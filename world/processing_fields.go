@@ -0,0 +1,70 @@
+package world
+
+import (
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+// fieldWorker processes per-Tile fields (temperature, pressure, light, pheromones, etc.) from the input job queue.
+// Job is a grid stripe index (for per-stripe fields) or fieldJobGlobalIdx (for fields that require the whole grid).
+// Field workers run in parallel with Tile workers: both are reading the current Map state, fields are double-buffered.
+func (m *Map) fieldWorker() {
+	for jobIdx := range m.procFieldJobCh {
+		if jobIdx == fieldJobGlobalIdx {
+			m.processFieldGlobal()
+		} else {
+			m.processFieldStripe(jobIdx, &m.procFieldActions[jobIdx])
+		}
+		m.procFieldWorkerWG.Done()
+	}
+}
+
+// startFieldsProcessing fills up the field workers jobs queue (one job per grid stripe and a global one).
+func (m *Map) startFieldsProcessing() {
+	for i := 0; i < len(m.procFieldActions); i++ {
+		m.procFieldActions[i] = m.procFieldActions[i][:0]
+
+		m.procFieldWorkerWG.Add(1)
+		m.procFieldJobCh <- i
+	}
+
+	m.procFieldWorkerWG.Add(1)
+	m.procFieldJobCh <- fieldJobGlobalIdx
+}
+
+// finishFieldsProcessing waits for field workers to finish and makes the next fields state current.
+func (m *Map) finishFieldsProcessing() {
+	m.procFieldWorkerWG.Wait()
+
+	m.heat.Swap()
+	m.pressure.Swap()
+	m.light.Swap()
+	for _, field := range m.pheromones {
+		field.Swap()
+	}
+}
+
+// processFieldStripe updates all the per-stripe fields within a single vertical grid stripe.
+func (m *Map) processFieldStripe(stripeIdx int, output *[]types.Action) {
+	if m.monitor != nil {
+		defer m.monitor.TrackOpDuration("Map.processFieldStripe")()
+	}
+
+	stripeWidth := m.width / fieldStripesNum
+	xFrom, xTo := stripeIdx*stripeWidth, (stripeIdx+1)*stripeWidth
+	if stripeIdx == fieldStripesNum-1 {
+		xTo = m.width
+	}
+
+	m.processHeat(xFrom, xTo, output)
+	m.processLight(xFrom, xTo)
+	m.processPheromones(xFrom, xTo)
+}
+
+// processFieldGlobal updates all the fields that require the whole grid state.
+func (m *Map) processFieldGlobal() {
+	if m.monitor != nil {
+		defer m.monitor.TrackOpDuration("Map.processFieldGlobal")()
+	}
+
+	m.processPressure()
+}
@@ -1,6 +1,8 @@
 package world
 
 import (
+	"math"
+
 	"github.com/itiky/goPixelWorld/pkg"
 	"github.com/itiky/goPixelWorld/world/closerange"
 	"github.com/itiky/goPixelWorld/world/collision"
@@ -20,15 +22,27 @@ func (m *Map) processingWorker() {
 			m.procActions[i] = m.procActions[i][:0]
 		}
 
+		// Start fields processing in parallel
+		m.updateDaylight()
+		m.startFieldsProcessing()
+
+		// Prepare the attraction sources (read-only while Tiles are processed)
+		m.buildAttractionTree()
+		// Evolve the wind field (read-only while Tiles are processed)
+		m.processWind()
+
 		// Fill up the jobs queue and for it to be processed
 		m.iterateNonEmptyTiles(func(tile *types.Tile) {
 			m.procTileWorkerWG.Add(1)
 			m.procTileJobCh <- tile
 		})
 		m.procTileWorkerWG.Wait()
+		m.finishFieldsProcessing()
 
 		// Alter the Map state
 		m.processActions()
+		m.processBonds()
+		m.processBodies()
 
 		// Prepare the output buffer
 		pixelIdx := 0
@@ -40,6 +54,7 @@ func (m *Map) processingWorker() {
 			pixelIdx++
 		})
 		m.procOutput[pixelIdx].Ready = false
+		m.prepareOverlayOutput()
 
 		// Ack the processing round
 		m.procAckCh <- struct{}{}
@@ -49,6 +64,8 @@ func (m *Map) processingWorker() {
 // tileWorker processes a single Tile state update from the input job queue.
 func (m *Map) tileWorker(id int) {
 	tileEnv := closerange.NewEnvironment(nil)
+	tileEnv.SetPheromoneReader(m.pheromoneAt)
+	tileEnv.SetPathFinder(m.findPath)
 	collisionEnv := collision.NewEnvironment(pkg.DirectionTop, nil, nil)
 
 	for tile := range m.procTileJobCh {
@@ -74,6 +91,19 @@ func (m *Map) processTile(tile *types.Tile, tileEnv *closerange.Environment, col
 		defer m.monitor.TrackOpDuration("Map.processTile")()
 	}
 
+	// Check bonds integrity
+	pushActions(m.buildBondActions(tile)...)
+
+	// Apply the inverse-square attraction
+	if forceVec, ok := m.attractionForce(tile); ok {
+		pushActions(types.NewAddForce(tile.Pos, tile.Particle.ID(), forceVec))
+	}
+
+	// Skip rigid body members (moved by the body)
+	if tile.Particle.BodyID() != 0 {
+		return
+	}
+
 	// Self-update
 	tile.Particle.UpdateState()
 
@@ -84,16 +114,88 @@ func (m *Map) processTile(tile *types.Tile, tileEnv *closerange.Environment, col
 	}
 
 	// Process Tile movement based on path to a target Tile (where this one want to move to)
-	targetEmptyTile, processCollisionEnv := m.buildCollisionEnv(tile, collisionEnv)
-	if targetEmptyTile != nil {
-		pushActions(types.NewMoveTile(tile.Pos, tile.Particle.ID(), targetEmptyTile.Pos))
+	targetTile := tile.TargetTile()
+	if targetTile == nil {
+		// Particle "doesn't want to move"
+		return
 	}
-	if processCollisionEnv {
-		collisionEnv.TargetMaterial().ProcessCollision(collisionEnv)
+
+	sourceTile := tile
+	for bounceIdx := 0; ; bounceIdx++ {
+		lastFreePos, travelLeft, processCollisionEnv := m.buildCollisionEnv(sourceTile, targetTile.Pos, collisionEnv)
+		if !lastFreePos.Equal(sourceTile.Pos) {
+			pushActions(types.NewMoveTile(sourceTile.Pos, tile.Particle.ID(), lastFreePos))
+			sourceTile = types.NewTile(lastFreePos, sourceTile.Particle)
+		}
+		if !processCollisionEnv {
+			return
+		}
+
+		// Density-based displacement and swimming are common for all Materials
+		if !collisionEnv.DisplaceByDensity() && !collisionEnv.SwimThrough() {
+			collisionEnv.TargetMaterial().ProcessCollision(collisionEnv)
+		}
 		pushActions(collisionEnv.Actions()...)
+
+		// Continue the movement with the rest of the path (the collision has reflected the source)
+		if bounceIdx+1 >= maxCollisionBounces || travelLeft < 1.0 {
+			return
+		}
+
+		forceBefore := sourceTile.Particle.ForceVector()
+		forceAfter, isMovable := simulateSourceForce(sourceTile, collisionEnv.Actions())
+		if !isMovable || forceAfter.Magnitude() < 1.0 || forceAfter == forceBefore {
+			return
+		}
+
+		travelVec := forceAfter.SetMagnitude(travelLeft * math.Min(forceAfter.Magnitude()/forceBefore.Magnitude(), 1.0))
+		targetTile = types.NewTile(
+			types.NewPosition(
+				sourceTile.Pos.X+int(travelVec.X()),
+				sourceTile.Pos.Y+int(travelVec.Y()),
+			),
+			nil,
+		)
+		if targetTile.Pos.Equal(sourceTile.Pos) {
+			return
+		}
+		sourceTile = types.NewTile(sourceTile.Pos, sourceTile.Particle.CopyWithForce(forceAfter))
 	}
 }
 
+// simulateSourceForce returns the source Particle force Vector as it would be after the collision Actions are applied.
+// Returns false if the source Particle is moved / replaced by one of those Actions (movement can't be continued).
+func simulateSourceForce(sourceTile *types.Tile, actions []types.Action) (pkg.Vector, bool) {
+	forceVec := sourceTile.Particle.ForceVector()
+	sourceID := sourceTile.Particle.ID()
+
+	for _, aBz := range actions {
+		if swapAction, ok := aBz.(*types.SwapTiles); ok && swapAction.SwapParticleID == sourceID {
+			return forceVec, false
+		}
+		if aBz.GetParticleID() != sourceID {
+			continue
+		}
+
+		switch a := aBz.(type) {
+		case *types.MultiplyForce:
+			forceVec = forceVec.MultiplyByK(a.K)
+		case *types.ReflectForce:
+			forceVec = forceVec.Reflect(a.Horizontal, a.Vertical)
+		case *types.AddForce:
+			forceVec = forceVec.Add(a.ForceVec)
+		case *types.AlterForce:
+			forceVec = a.NewForceVec
+		case *types.RotateForce:
+			forceVec = forceVec.Rotate(a.Angle)
+		case *types.MoveTile, *types.SwapTiles, *types.TileReplace:
+			return forceVec, false
+		}
+	}
+
+	return forceVec, true
+}
+
 // buildTileEnv builds a Tile surrounding state.
 // Returns true, if state processing is required.
 // Environment filling is based on Tile's Material requirements.
@@ -103,11 +205,21 @@ func (m *Map) buildTileEnv(sourceTile *types.Tile, tileEnv *closerange.Environme
 		return false
 	}
 
+	// Attraction sources are processed by the attraction tree in the inverse-square modes
+	if m.gravitonMode != types.GravitonModeCircle && sourceTile.Particle.Material().Attraction() != 0.0 {
+		return false
+	}
+
 	if m.monitor != nil {
 		defer m.monitor.TrackOpDuration("Map.buildTileEnv")()
 	}
 
 	tileEnv.Reset(sourceTile)
+	tileEnv.SetTemperature(m.heat.Get(sourceTile.Pos.X, sourceTile.Pos.Y))
+	tileEnv.SetPressure(m.pressure.Get(sourceTile.Pos.X, sourceTile.Pos.Y))
+	tileEnv.SetWind(m.windAt(sourceTile.Pos.X, sourceTile.Pos.Y))
+	tileEnv.SetGravity(m.gravity.at(sourceTile.Pos.X, sourceTile.Pos.Y))
+	tileEnv.SetLight(m.light.Get(sourceTile.Pos.X, sourceTile.Pos.Y))
 	switch envType {
 	case types.MaterialCloseRangeTypeSelfOnly:
 	case types.MaterialCloseRangeTypeSurrounding:
@@ -144,48 +256,73 @@ func (m *Map) buildTileEnv(sourceTile *types.Tile, tileEnv *closerange.Environme
 	return true
 }
 
-// buildCollisionEnv builds a path to Tile's target position, walks through it and build a collision state if occurred.
-// Returns non-nil target Tile if source Tile can freely move to it (no path collisions).
-// Returns true in case of a collision meaning it should be processed by the source Material.
-func (m *Map) buildCollisionEnv(sourceTile *types.Tile, collisionEnv *collision.Environment) (*types.Tile, bool) {
+// buildCollisionEnv builds a path to the target Position, walks through it and build a collision state if occurred.
+// Returns the last free Position on the path the source Tile can freely move to (might be the source Position itself).
+// Returns the travel distance left after the collision (the rest of the path length).
+// Returns true in case of a collision meaning it should be processed by the target Material.
+func (m *Map) buildCollisionEnv(sourceTile *types.Tile, targetPos types.Position, collisionEnv *collision.Environment) (types.Position, float64, bool) {
 	if m.monitor != nil {
 		defer m.monitor.TrackOpDuration("Map.buildCollisionEnv")()
 	}
 
-	// Get the target Tile based on the current Particle's force Vector
-	targetTile := sourceTile.TargetTile()
-	if targetTile == nil {
-		// Particle "doesn't want to move"
-		return nil, false
-	}
-
 	// Build a path to the target with the grid limitations
-	pathToTarget := sourceTile.Pos.CreatePathTo(targetTile.Pos, m.width, m.height)
-	for _, pathPos := range pathToTarget {
-		targetTile = m.getTile(pathPos.X, pathPos.Y)
-		if targetTile.HasParticle() {
-			break
+	lastFreePos := sourceTile.Pos
+	pathToTarget := sourceTile.Pos.CreatePathTo(targetPos, m.width, m.height)
+
+	var targetTile *types.Tile
+	for pathIdx, pathPos := range pathToTarget {
+		// The source Particle itself might be on the path (bounced back through the original Position)
+		tile := m.getTile(pathPos.X, pathPos.Y)
+		if !tile.HasParticle() || tile.Particle.ID() == sourceTile.Particle.ID() {
+			lastFreePos = pathPos
+			continue
 		}
+
+		targetTile = tile
+		pathToTarget = pathToTarget[pathIdx+1:]
+		break
 	}
 
-	// Check if the last path Tile is empty
-	if !targetTile.HasParticle() {
-		return targetTile, false
+	// Check if the path is free
+	if targetTile == nil {
+		return lastFreePos, 0.0, false
 	}
+	sourceTile = types.NewTile(lastFreePos, sourceTile.Particle)
 
 	// Build collision direction (defines the target Particle neighbours order)
 	colDirection := pkg.NewDirectionFromCoords(sourceTile.Pos.X, sourceTile.Pos.Y, targetTile.Pos.X, targetTile.Pos.Y)
+	gravityDirection := m.gravity.direction(sourceTile.Pos.X, sourceTile.Pos.Y)
 
 	collisionEnv.Reset(colDirection, sourceTile, targetTile)
+	collisionEnv.SetGravityDirection(gravityDirection)
+
+	// Falling sand-like / liquid source neighbours are relative to the local gravity (piles and pools are formed "downwards")
+	sourceMaterial := sourceTile.Particle.Material()
+	isSourceGranular := sourceMaterial.IsFlagged(types.MaterialFlagIsSand)
+	frameDirection := colDirection
+	if (isSourceGranular || sourceMaterial.IsFlagged(types.MaterialFlagIsLiquid)) && isDirectionIn(colDirection, gravityDirection.Sector(1)) {
+		frameDirection = gravityDirection
+	}
+
+	// Granular source requires its wetness and slide drop depths (angle of repose check)
+	dropDx, dropDy := frameDirection.Offset()
+	if isSourceGranular {
+		collisionEnv.SetSourceWet(m.isNextToLiquid(sourceTile.Pos))
+	}
+
 	setNeighbor := func(dir pkg.Direction, dx, dy int) {
 		x, y := targetTile.Pos.X+dx, targetTile.Pos.Y+dy
 		if !m.isPositionValid(x, y) {
 			return
 		}
 		collisionEnv.SetNeighbour(dir, m.getTile(x, y))
+
+		if isSourceGranular && (dir == pkg.DirectionLeft || dir == pkg.DirectionRight) {
+			collisionEnv.SetNeighbourDropDepth(dir, m.emptyTilesDepth(x, y, dropDx, dropDy))
+		}
 	}
 
-	switch colDirection {
+	switch frameDirection {
 	case pkg.DirectionTop:
 		setNeighbor(pkg.DirectionTopLeft, 1, 1)
 		setNeighbor(pkg.DirectionLeft, 1, 0)
@@ -236,5 +373,50 @@ func (m *Map) buildCollisionEnv(sourceTile *types.Tile, collisionEnv *collision.
 		setNeighbor(pkg.DirectionTopRight, 0, -1)
 	}
 
-	return nil, true
+	return lastFreePos, float64(len(pathToTarget)), true
+}
+
+// isDirectionIn checks if the Direction is in the set.
+func isDirectionIn(dir pkg.Direction, dirs []pkg.Direction) bool {
+	for _, d := range dirs {
+		if d == dir {
+			return true
+		}
+	}
+
+	return false
+}
+
+// emptyTilesDepth returns the number of consecutive empty Tiles starting from the Position in the {dx, dy} direction.
+func (m *Map) emptyTilesDepth(x, y, dx, dy int) int {
+	const maxDepth = 5
+
+	depth := 0
+	for ; depth < maxDepth; depth++ {
+		if !m.isPositionValid(x, y) || m.getTile(x, y).HasParticle() {
+			break
+		}
+		x, y = x+dx, y+dy
+	}
+
+	return depth
+}
+
+// isNextToLiquid checks if any of the Position neighbours is a liquid.
+func (m *Map) isNextToLiquid(pos types.Position) bool {
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			x, y := pos.X+dx, pos.Y+dy
+			if (dx == 0 && dy == 0) || !m.isPositionValid(x, y) {
+				continue
+			}
+
+			tile := m.getTile(x, y)
+			if tile.HasParticle() && tile.Particle.Material().IsFlagged(types.MaterialFlagIsLiquid) {
+				return true
+			}
+		}
+	}
+
+	return false
 }
@@ -0,0 +1,55 @@
+package world
+
+import (
+	"math"
+
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+const (
+	// seasonSnowTemperature defines the max ambient temperature for clouds to drop the Snow instead of the rain.
+	seasonSnowTemperature = 0.0
+)
+
+// initSeason sets the year cycle to the middle of the start season.
+func (m *Map) initSeason() {
+	cfg := m.natureConfig
+
+	m.yearTime = int((float64(cfg.StartSeason) + 0.5) * float64(cfg.SeasonLength))
+	m.season = cfg.StartSeason
+	m.ambientTemp = heatAmbientTemperature
+	if m.natureEnabled {
+		m.ambientTemp = seasonTemperature(cfg, float64(cfg.StartSeason)+0.5)
+	}
+}
+
+// updateSeason advances the year cycle and updates the current season and the ambient temperature.
+// The start season lasts forever if the season length is not set.
+func (m *Map) updateSeason() {
+	cfg := m.natureConfig
+
+	phase := float64(cfg.StartSeason) + 0.5
+	if cfg.SeasonLength > 0 {
+		m.yearTime = (m.yearTime + 1) % (cfg.SeasonLength * types.SeasonsNum)
+		phase = float64(m.yearTime) / float64(cfg.SeasonLength)
+	}
+
+	m.season = types.Season(int(phase) % types.SeasonsNum)
+	m.ambientTemp = seasonTemperature(cfg, phase)
+}
+
+// seasonTemperature returns the ambient temperature for the year cycle phase [0.0, SeasonsNum) (a season index plus its progress).
+// Temperature follows a cosine curve: the warmest in the middle of the summer, the coldest in the middle of the winter.
+func seasonTemperature(cfg types.NatureConfig, phase float64) float64 {
+	const summerMiddlePhase = float64(types.SeasonSummer) + 0.5
+
+	midTemp := (cfg.SummerTemperature + cfg.WinterTemperature) / 2.0
+	ampTemp := (cfg.SummerTemperature - cfg.WinterTemperature) / 2.0
+
+	return midTemp + ampTemp*math.Cos(2.0*math.Pi*(phase-summerMiddlePhase)/types.SeasonsNum)
+}
+
+// Season returns the current season and the ambient temperature.
+func (m *Map) Season() (types.Season, float64) {
+	return m.season, m.ambientTemp
+}
@@ -16,10 +16,28 @@ func (m *Map) initGrid(width, height int) {
 		delete(m.particles, pID)
 	}
 
+	m.bodies = make(map[int]*rigidBody)
+	m.bondedParticles = make(map[uint64]bool)
 	m.grid = make([][]*types.Tile, m.width)
+	m.heat = newScalarField(m.width, m.height, heatAmbientTemperature)
+	m.pressure = newScalarField(m.width, m.height, 0.0)
+	m.light = newScalarField(m.width, m.height, 1.0)
+	for i := range m.pheromones {
+		m.pheromones[i] = newScalarField(m.width, m.height, 0.0)
+	}
+	m.pathFinder = newPathFinder()
+	if m.windConfig != nil {
+		m.wind = newWindField(*m.windConfig, m.width, m.height)
+	}
+	m.gravity.defCenter = types.Position{X: m.width / 2, Y: m.height / 2}
+	m.pressureLabels = make([][]int, m.width)
+	m.pressureSupport = make([][]liquidSupport, m.width)
 	m.procOutput = make([]types.Pixel, 0, m.width*m.height)
+	m.procOverlayOutput = make([]types.Pixel, m.width*m.height+1)
 	for x := 0; x < m.width; x++ {
 		m.grid[x] = make([]*types.Tile, m.height)
+		m.pressureLabels[x] = make([]int, m.height)
+		m.pressureSupport[x] = make([]liquidSupport, m.height)
 		for y := 0; y < m.height; y++ {
 			var material types.Material
 			if x == 0 || y == 0 || x == m.width-1 || y == m.height-1 {
@@ -93,8 +111,9 @@ func (m *Map) moveTile(sourceTile *types.Tile, targetPos types.Position) {
 	targetTile := m.getTile(targetPos.X, targetPos.Y)
 	targetTile.Particle = sourceTile.Particle
 	sourceTile.Particle = nil
+	m.heat.SwapValues(sourceTile.Pos.X, sourceTile.Pos.Y, targetPos.X, targetPos.Y)
 
-	targetTile.Particle.OnMove()
+	targetTile.Particle.OnMove(targetPos.X-sourceTile.Pos.X, targetPos.Y-sourceTile.Pos.Y)
 	m.particles[targetTile.Particle.ID()] = targetTile
 }
 
@@ -105,9 +124,10 @@ func (m *Map) swapTiles(tile1, tile2 *types.Tile) {
 	}
 
 	tile1.Particle, tile2.Particle = tile2.Particle, tile1.Particle
+	m.heat.SwapValues(tile1.Pos.X, tile1.Pos.Y, tile2.Pos.X, tile2.Pos.Y)
 
-	tile1.Particle.OnMove()
-	tile2.Particle.OnMove()
+	tile1.Particle.OnMove(tile1.Pos.X-tile2.Pos.X, tile1.Pos.Y-tile2.Pos.Y)
+	tile2.Particle.OnMove(tile2.Pos.X-tile1.Pos.X, tile2.Pos.Y-tile1.Pos.Y)
 	m.particles[tile1.Particle.ID()] = tile1
 	m.particles[tile2.Particle.ID()] = tile2
 }
@@ -20,6 +20,11 @@ const (
 	ActionTypeTileReplace
 	ActionTypeTileAdd
 	ActionTypeUpdateStateParam
+	ActionTypeBondCreate
+	ActionTypeBondRemove
+	ActionTypeExplode
+	ActionTypeConsume
+	ActionTypeDepositPheromone
 )
 
 // Action defines the contract for all Action types.
@@ -226,6 +231,7 @@ func (a ReduceHealth) Type() ActionType {
 type TileReplace struct {
 	ActionBase
 	Material Material
+	State    ParticleState // new Particle initial state params (optional)
 }
 
 func NewTileReplace(tilePos Position, tilePID uint64, material Material) *TileReplace {
@@ -238,6 +244,13 @@ func NewTileReplace(tilePos Position, tilePID uint64, material Material) *TileRe
 	}
 }
 
+func NewTileReplaceWithState(tilePos Position, tilePID uint64, material Material, state ParticleState) *TileReplace {
+	a := NewTileReplace(tilePos, tilePID, material)
+	a.State = state
+
+	return a
+}
+
 func (a TileReplace) Type() ActionType {
 	return ActionTypeTileReplace
 }
@@ -246,6 +259,7 @@ func (a TileReplace) Type() ActionType {
 type TileAdd struct {
 	ActionBase
 	Material Material
+	State    ParticleState // initial state params (optional)
 }
 
 func NewTileAdd(tilePos Position, material Material) *TileAdd {
@@ -257,6 +271,13 @@ func NewTileAdd(tilePos Position, material Material) *TileAdd {
 	}
 }
 
+func NewTileAddWithState(tilePos Position, material Material, state ParticleState) *TileAdd {
+	a := NewTileAdd(tilePos, material)
+	a.State = state
+
+	return a
+}
+
 func (a TileAdd) Type() ActionType {
 	return ActionTypeTileAdd
 }
@@ -282,3 +303,119 @@ func NewUpdateStateParam(tilePos Position, tilePID uint64, paramKey string, para
 func (a UpdateStateParam) Type() ActionType {
 	return ActionTypeUpdateStateParam
 }
+
+// BondCreate defines an Action which bonds two Particles with a distance constraint.
+type BondCreate struct {
+	ActionBase
+	BondTilePos    Position
+	BondParticleID uint64
+	Bond           ParticleBond
+}
+
+func NewBondCreate(tile1Pos Position, tile1PID uint64, tile2Pos Position, tile2PID uint64, bond ParticleBond) *BondCreate {
+	return &BondCreate{
+		ActionBase: ActionBase{
+			TilePos:    tile1Pos,
+			ParticleID: tile1PID,
+		},
+		BondTilePos:    tile2Pos,
+		BondParticleID: tile2PID,
+		Bond:           bond,
+	}
+}
+
+func (a BondCreate) Type() ActionType {
+	return ActionTypeBondCreate
+}
+
+// BondRemove defines an Action which breaks a bond between two Particles.
+type BondRemove struct {
+	ActionBase
+	BondParticleID uint64
+}
+
+func NewBondRemove(tilePos Position, tilePID uint64, bondPID uint64) *BondRemove {
+	return &BondRemove{
+		ActionBase: ActionBase{
+			TilePos:    tilePos,
+			ParticleID: tilePID,
+		},
+		BondParticleID: bondPID,
+	}
+}
+
+func (a BondRemove) Type() ActionType {
+	return ActionTypeBondRemove
+}
+
+// Explode defines an Action which blasts a circle area around the Tile consuming the Particle.
+type Explode struct {
+	ActionBase
+	Radius            int     // blast radius
+	Impulse           float64 // outward force Vector magnitude at the center (decreases with the distance)
+	Heat              float64 // temperature at the center (decreases with the distance)
+	DestructionHealth float64 // health damage at the center (decreases with the distance)
+}
+
+func NewExplode(tilePos Position, tilePID uint64, radius int, impulse, heat, destructionHealth float64) *Explode {
+	return &Explode{
+		ActionBase: ActionBase{
+			TilePos:    tilePos,
+			ParticleID: tilePID,
+		},
+		Radius:            radius,
+		Impulse:           impulse,
+		Heat:              heat,
+		DestructionHealth: destructionHealth,
+	}
+}
+
+func (a Explode) Type() ActionType {
+	return ActionTypeExplode
+}
+
+// Consume defines an Action which kills a prey Particle absorbing its health (the Tile's Particle is the consumer).
+type Consume struct {
+	ActionBase
+	PreyTilePos    Position
+	PreyParticleID uint64
+	AbsorbK        float64 // prey health part the consumer gains
+}
+
+func NewConsume(tilePos Position, tilePID uint64, preyTilePos Position, preyPID uint64, absorbK float64) *Consume {
+	return &Consume{
+		ActionBase: ActionBase{
+			TilePos:    tilePos,
+			ParticleID: tilePID,
+		},
+		PreyTilePos:    preyTilePos,
+		PreyParticleID: preyPID,
+		AbsorbK:        absorbK,
+	}
+}
+
+func (a Consume) Type() ActionType {
+	return ActionTypeConsume
+}
+
+// DepositPheromone defines an Action which adds a pheromone amount to the Tile (the scent stays on the Tile).
+type DepositPheromone struct {
+	ActionBase
+	PheromoneType PheromoneType
+	Amount        float64
+}
+
+func NewDepositPheromone(tilePos Position, tilePID uint64, pheromoneType PheromoneType, amount float64) *DepositPheromone {
+	return &DepositPheromone{
+		ActionBase: ActionBase{
+			TilePos:    tilePos,
+			ParticleID: tilePID,
+		},
+		PheromoneType: pheromoneType,
+		Amount:        amount,
+	}
+}
+
+func (a DepositPheromone) Type() ActionType {
+	return ActionTypeDepositPheromone
+}
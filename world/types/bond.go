@@ -0,0 +1,34 @@
+package types
+
+// BondType defines a Particle bond (distance constraint) type.
+type BondType int
+
+const (
+	BondTypeNone BondType = iota
+	// BondTypeRope resists stretching only (ropes, chains, cloth).
+	BondTypeRope
+	// BondTypeSpring resists both stretching and compression (jelly-like soft bodies).
+	BondTypeSpring
+)
+
+// AllBondTypes is a list of all known BondTypes (in the switch order).
+var AllBondTypes = []BondType{BondTypeNone, BondTypeRope, BondTypeSpring}
+
+func (t BondType) String() string {
+	switch t {
+	case BondTypeNone:
+		return "Bond"
+	case BondTypeRope:
+		return "Rope"
+	case BondTypeSpring:
+		return "Jelly"
+	}
+
+	return ""
+}
+
+// ParticleBond defines a distance constraint to another Particle.
+type ParticleBond struct {
+	Type       BondType
+	RestLength float64 // distance the constraint keeps
+}
@@ -0,0 +1,131 @@
+package types
+
+import (
+	"math"
+	"math/rand"
+)
+
+// BugTrait defines a heritable Bug trait.
+type BugTrait int
+
+const (
+	BugTraitSpeed       BugTrait = iota // max movement speed
+	BugTraitSplitHealth                 // the amount of health to split (create a new Bug)
+	BugTraitFeedRate                    // grass feed speed (the same amount of health is recovered)
+	BugTraitSight                       // food search radius [Tiles]
+	BugTraitComfortTemp                 // the most comfortable temperature (the climate cost is applied outside the comfort range)
+	BugTraitsNum
+)
+
+// bugGeneStateScale defines a trait value multiplier to keep it in a Particle state (which is int based).
+const bugGeneStateScale = 1000.0
+
+var AllBugTraits = []BugTrait{
+	BugTraitSpeed,
+	BugTraitSplitHealth,
+	BugTraitFeedRate,
+	BugTraitSight,
+	BugTraitComfortTemp,
+}
+
+// bugTraitRanges defines trait value limits (mutations are clamped).
+var bugTraitRanges = [BugTraitsNum][2]float64{
+	BugTraitSpeed:       {0.5, 3.0},
+	BugTraitSplitHealth: {300.0, 2000.0},
+	BugTraitFeedRate:    {0.5, 2.0},
+	BugTraitSight:       {math.Sqrt2, 5.0},
+	BugTraitComfortTemp: {-20.0, 40.0},
+}
+
+func (t BugTrait) String() string {
+	switch t {
+	case BugTraitSpeed:
+		return "Speed"
+	case BugTraitSplitHealth:
+		return "Split"
+	case BugTraitFeedRate:
+		return "Feed"
+	case BugTraitSight:
+		return "Sight"
+	case BugTraitComfortTemp:
+		return "Comfort"
+	default:
+		return "Unknown"
+	}
+}
+
+// Range returns the trait [min, max] value range.
+func (t BugTrait) Range() (float64, float64) {
+	return bugTraitRanges[t][0], bugTraitRanges[t][1]
+}
+
+// StateParam returns the Particle state param key the trait is stored with.
+func (t BugTrait) StateParam() string {
+	return "bug_gene_" + t.String()
+}
+
+// BugGenome defines a set of heritable Bug traits stored on the Particle (within its state).
+// A split Bug passes the genome to the child with random mutations.
+type BugGenome [BugTraitsNum]float64
+
+// DefaultBugGenome returns the BugGenome all Bugs created by the editor start with.
+func DefaultBugGenome() BugGenome {
+	return BugGenome{
+		BugTraitSpeed:       1.8,
+		BugTraitSplitHealth: 1000.0,
+		BugTraitFeedRate:    1.0,
+		BugTraitSight:       math.Sqrt2 * 3,
+		BugTraitComfortTemp: 20.0,
+	}
+}
+
+// NewBugGenomeFromState reads the BugGenome from a Particle state.
+// {getParam} is a state getter (TileEnvironment.StateParam, Particle.GetStateParam).
+// The default genome is returned if the state has no genome (a new Bug).
+func NewBugGenomeFromState(getParam func(key string) int) BugGenome {
+	if getParam(BugTraitSpeed.StateParam()) == 0 {
+		return DefaultBugGenome()
+	}
+
+	var g BugGenome
+	for _, t := range AllBugTraits {
+		g[t] = float64(getParam(t.StateParam())) / bugGeneStateScale
+	}
+
+	return g
+}
+
+// State returns the Particle state params to store the genome with.
+func (g BugGenome) State() ParticleState {
+	state := make(ParticleState, BugTraitsNum)
+	for _, t := range AllBugTraits {
+		state[t.StateParam()] = int(math.Round(g[t] * bugGeneStateScale))
+	}
+
+	return state
+}
+
+// Mutate returns a genome copy with mutated traits.
+// Each trait is mutated with the {chance} by a normally distributed step ({strength} defines the step SD relative to the trait range).
+func (g BugGenome) Mutate(chance, strength float64) BugGenome {
+	for _, t := range AllBugTraits {
+		if rand.Float64() >= chance {
+			continue
+		}
+
+		minV, maxV := t.Range()
+		v := g[t] + rand.NormFloat64()*strength*(maxV-minV)
+		g[t] = math.Max(minV, math.Min(maxV, v))
+	}
+
+	return g
+}
+
+// BugTraitHistogramBins defines the number of BugTraitStats histogram bins.
+const BugTraitHistogramBins = 10
+
+// BugTraitStats defines a single trait distribution within the Bug population.
+type BugTraitStats struct {
+	Mean, Min, Max float64
+	Histogram      [BugTraitHistogramBins]int // number of Bugs per trait range bin
+}
@@ -0,0 +1,29 @@
+package types
+
+// GravitonMode defines how the attraction (Graviton / AntiGraviton) forces are calculated.
+type GravitonMode int
+
+const (
+	// GravitonModeCircle applies a constant force to Particles in a fixed circle range.
+	GravitonModeCircle GravitonMode = iota
+	// GravitonModeInverseSquare applies a force with an inverse-square falloff and an unlimited range.
+	GravitonModeInverseSquare
+	// GravitonModeNBody is GravitonModeInverseSquare with massive ordinary Particles attracting others as well.
+	GravitonModeNBody
+)
+
+// AllGravitonModes is a list of all known GravitonModes (in the switch order).
+var AllGravitonModes = []GravitonMode{GravitonModeCircle, GravitonModeInverseSquare, GravitonModeNBody}
+
+func (m GravitonMode) String() string {
+	switch m {
+	case GravitonModeCircle:
+		return "GravCirc"
+	case GravitonModeInverseSquare:
+		return "GravInvS"
+	case GravitonModeNBody:
+		return "N-Body"
+	}
+
+	return ""
+}
@@ -0,0 +1,25 @@
+package types
+
+// GravityMode defines how the gravity direction is calculated for a Position.
+type GravityMode int
+
+const (
+	// GravityModeDirectional applies the same gravity Vector everywhere (down by default, any angle is possible).
+	GravityModeDirectional GravityMode = iota
+	// GravityModeRadial pulls Particles towards the closest gravity center ("planets").
+	GravityModeRadial
+)
+
+// AllGravityModes is a list of all known GravityModes (in the switch order).
+var AllGravityModes = []GravityMode{GravityModeDirectional, GravityModeRadial}
+
+func (m GravityMode) String() string {
+	switch m {
+	case GravityModeDirectional:
+		return "GravDir"
+	case GravityModeRadial:
+		return "Planet"
+	}
+
+	return ""
+}
@@ -7,6 +7,15 @@ const (
 	InputActionCreateParticles InputActionType = iota
 	InputActionDeleteParticles
 	InputActionFlipGravity
+	InputActionSetOverlay
+	InputActionCreateBody
+	InputActionSetGravitonMode
+	InputActionSetGravityMode
+	InputActionRotateGravity
+	InputActionAddGravityCenter
+	InputActionClearGravityCenters
+	InputActionSetNaturePreset
+	InputActionStrikeLightning
 )
 
 // InputAction defines a common input action interface.
@@ -20,6 +29,8 @@ type CreateParticlesInputAction struct {
 	Radius     int       // if GT 1, creates a set of Particles in a circle area
 	Material   MaterialI // new Particle(s) Material
 	ApplyForce bool      // if set, apply a random force to a new Particle(s).
+	Bond       BondType  // if set, bonds new Particle(s) to the ones created before within the same stroke
+	StrokeID   int       // continuous drawing ID (the Bond tool stroke)
 }
 
 func (a CreateParticlesInputAction) Type() InputActionType {
@@ -36,9 +47,90 @@ func (a DeleteParticlesInputAction) Type() InputActionType {
 	return InputActionDeleteParticles
 }
 
-// FlipGravityInputAction defines a request to flip the vertical gravity.
+// FlipGravityInputAction defines a request to flip the gravity.
 type FlipGravityInputAction struct{}
 
 func (a FlipGravityInputAction) Type() InputActionType {
 	return InputActionFlipGravity
 }
+
+// SetOverlayInputAction defines a request to switch the Map state overlay.
+type SetOverlayInputAction struct {
+	Overlay OverlayType
+}
+
+func (a SetOverlayInputAction) Type() InputActionType {
+	return InputActionSetOverlay
+}
+
+// CreateBodyInputAction defines a request to group existing Particles painted within a stroke into a rigid body.
+type CreateBodyInputAction struct {
+	Positions []Position // stroke Positions to group Particles around
+	Radius    int        // circle area radius to group Particles in (per Position)
+	Pinned    bool       // if set, the body only rotates around its center
+}
+
+func (a CreateBodyInputAction) Type() InputActionType {
+	return InputActionCreateBody
+}
+
+// SetGravitonModeInputAction defines a request to switch the Graviton / AntiGraviton attraction mode.
+type SetGravitonModeInputAction struct {
+	Mode GravitonMode
+}
+
+func (a SetGravitonModeInputAction) Type() InputActionType {
+	return InputActionSetGravitonMode
+}
+
+// SetGravityModeInputAction defines a request to switch the gravity mode (directional / radial).
+type SetGravityModeInputAction struct {
+	Mode GravityMode
+}
+
+func (a SetGravityModeInputAction) Type() InputActionType {
+	return InputActionSetGravityMode
+}
+
+// RotateGravityInputAction defines a request to rotate the directional gravity.
+type RotateGravityInputAction struct {
+	Angle float64 // rotation angle delta [rad] (clockwise for a positive one)
+}
+
+func (a RotateGravityInputAction) Type() InputActionType {
+	return InputActionRotateGravity
+}
+
+// AddGravityCenterInputAction defines a request to add a new radial gravity center ("planet").
+type AddGravityCenterInputAction struct {
+	X, Y int // center Position
+}
+
+func (a AddGravityCenterInputAction) Type() InputActionType {
+	return InputActionAddGravityCenter
+}
+
+// ClearGravityCentersInputAction defines a request to remove all the radial gravity centers (the grid center is used).
+type ClearGravityCentersInputAction struct{}
+
+func (a ClearGravityCentersInputAction) Type() InputActionType {
+	return InputActionClearGravityCenters
+}
+
+// SetNaturePresetInputAction defines a request to switch the nature effects preset (weather).
+type SetNaturePresetInputAction struct {
+	Preset NaturePreset
+}
+
+func (a SetNaturePresetInputAction) Type() InputActionType {
+	return InputActionSetNaturePreset
+}
+
+// StrikeLightningInputAction defines a request to strike a lightning bolt from the top of the grid.
+type StrikeLightningInputAction struct {
+	X int // bolt start Position (the top grid row)
+}
+
+func (a StrikeLightningInputAction) Type() InputActionType {
+	return InputActionStrikeLightning
+}
@@ -24,6 +24,23 @@ const (
 	MaterialTypeGraviton
 	MaterialTypeAntiGraviton
 	MaterialTypeBug
+	MaterialTypeOil
+	MaterialTypeGunpowder
+	MaterialTypeTNT
+	MaterialTypeGlass
+	MaterialTypeSnow
+	MaterialTypeIce
+	MaterialTypeSeed
+	MaterialTypeTree
+	MaterialTypeLeaf
+	MaterialTypePredator
+	MaterialTypeAnt
+	MaterialTypeFish
+	MaterialTypeAlgae
+	MaterialTypeDeadMatter
+	MaterialTypeSoil
+	MaterialTypeLava
+	MaterialTypeObsidian
 )
 
 func (t MaterialType) String() string {
@@ -54,6 +71,40 @@ func (t MaterialType) String() string {
 		return "Anti-Graviton"
 	case MaterialTypeBug:
 		return "Bug"
+	case MaterialTypeOil:
+		return "Oil"
+	case MaterialTypeGunpowder:
+		return "Gunpowder"
+	case MaterialTypeTNT:
+		return "TNT"
+	case MaterialTypeGlass:
+		return "Glass"
+	case MaterialTypeSnow:
+		return "Snow"
+	case MaterialTypeIce:
+		return "Ice"
+	case MaterialTypeSeed:
+		return "Seed"
+	case MaterialTypeTree:
+		return "Tree"
+	case MaterialTypeLeaf:
+		return "Leaf"
+	case MaterialTypePredator:
+		return "Predator"
+	case MaterialTypeAnt:
+		return "Ant"
+	case MaterialTypeFish:
+		return "Fish"
+	case MaterialTypeAlgae:
+		return "Algae"
+	case MaterialTypeDeadMatter:
+		return "Dead Matter"
+	case MaterialTypeSoil:
+		return "Soil"
+	case MaterialTypeLava:
+		return "Lava"
+	case MaterialTypeObsidian:
+		return "Obsidian"
 	}
 
 	return ""
@@ -70,8 +121,75 @@ const (
 	MaterialFlagIsFlammable
 	MaterialFlagIsUnremovable
 	MaterialFlagIsUnmovable
+	MaterialFlagIsExplosive
+	MaterialFlagIsConductive
+	MaterialFlagIsSwimmer
 )
 
+// MaterialPhaseChange defines Material phase change type triggered by the temperature.
+type MaterialPhaseChange int
+
+const (
+	MaterialPhaseChangeMelt MaterialPhaseChange = iota
+	MaterialPhaseChangeBoil
+	MaterialPhaseChangeFreeze
+	MaterialPhaseChangeIgnite
+)
+
+// MaterialPhaseTransition defines a Material transition to another Material at the threshold temperature.
+type MaterialPhaseTransition struct {
+	Change      MaterialPhaseChange // transition type
+	Temperature float64             // threshold temperature
+	Material    MaterialType        // the resulting Material type
+}
+
+// IsTriggered checks if the transition should occur at the temperature.
+// Freeze is triggered below the threshold, all others above.
+func (t MaterialPhaseTransition) IsTriggered(temperature float64) bool {
+	if t.Change == MaterialPhaseChangeFreeze {
+		return temperature <= t.Temperature
+	}
+
+	return temperature >= t.Temperature
+}
+
+// MaterialThermal defines Material heat transfer properties.
+type MaterialThermal struct {
+	Conductivity      float64                   // [0.0, 1.0] how fast the temperature is equalized with neighbours (0.0 - insulator)
+	HeatCapacity      float64                   // the higher the value, the slower the temperature changes
+	SourceTemperature float64                   // if GT 0.0, the Particle is a heat source keeping at least this temperature
+	Transitions       []MaterialPhaseTransition // temperature triggered transitions
+}
+
+// MaterialFlow defines liquid and gas Material flow properties.
+type MaterialFlow struct {
+	Viscosity      float64 // [0.0, 1.0] flow resistance (0.0 - flows freely, 1.0 - doesn't flow)
+	DispersionRate float64 // [0.0, 1.0] the chance to move towards a lower pressure per processing round
+}
+
+// MaterialGranular defines sand-like Material pile properties.
+type MaterialGranular struct {
+	ReposeAngle    float64 // [0, Pi/2) the steepest stable pile slope angle [rad] (slides down if the slope is steeper)
+	Cohesion       float64 // [0.0, 1.0] the chance to hold on even if the slope is steep enough
+	WetReposeAngle float64 // ReposeAngle for a wet Particle (next to a liquid)
+	WetCohesion    float64 // Cohesion for a wet Particle (next to a liquid)
+}
+
+// MaterialCollisionProps defines a collision response for a source / target Material pair.
+type MaterialCollisionProps struct {
+	Restitution float64 // [0.0, 1.0] normal velocity restitution coefficient (0.0 - perfectly inelastic, 1.0 - elastic)
+	Friction    float64 // [0.0, 1.0] tangential velocity reduction (0.0 - slides, 1.0 - sticks)
+}
+
+// MaterialExplosion defines explosive Material properties.
+type MaterialExplosion struct {
+	TriggerTemperature float64 // the Particle explodes when its Tile temperature reaches the threshold
+	Radius             int     // blast radius
+	Impulse            float64 // outward force Vector magnitude at the blast center
+	Heat               float64 // temperature at the blast center
+	DestructionHealth  float64 // health damage at the blast center
+}
+
 // MaterialCloseRangeType defines Material surrounding environment filling that is required to self-process a Particle.
 // Used during the Tile processing step.
 type MaterialCloseRangeType int
@@ -97,6 +215,19 @@ type Material interface {
 	Mass() float64
 	// InitialHealth returns a Particle initial health.
 	InitialHealth() float64
+	// Thermal returns a Material heat transfer and phase change properties.
+	Thermal() MaterialThermal
+	// Flow returns a Material flow properties (for liquids and gases).
+	Flow() MaterialFlow
+	// Granular returns a Material pile properties (for sand-like Materials).
+	Granular() MaterialGranular
+	// Attraction returns a Material attraction strength used by the inverse-square Graviton modes (negative value repels).
+	Attraction() float64
+	// LightTransmission returns a Material light transmission share [0.0, 1.0] (0.0 - opaque, 1.0 - transparent).
+	LightTransmission() float64
+	// CollisionProps returns the collision response for a source Material colliding with this one.
+	// Returns false if the pair is not configured.
+	CollisionProps(sourceType MaterialType) (MaterialCollisionProps, bool)
 
 	// CloseRangeType returns a surrounding environment type that must be considered while building the closerange.Environment object.
 	CloseRangeType() MaterialCloseRangeType
@@ -126,16 +257,36 @@ type TileEnvironment interface {
 	ForceVec() pkg.Vector
 	// StateParam return the internal Particle state parameter value.
 	StateParam(key string) int
+	// Temperature returns the Particle's Tile current temperature.
+	Temperature() float64
+	// Pressure returns the Particle's Tile current pressure (liquids and gases only).
+	Pressure() float64
+	// Light returns the Particle's Tile current light level [0.0, 1.0] (sunlight reaching the Tile).
+	Light() float64
+	// Pheromone returns the pheromone level at the neighbour Tile in the {dir} direction (pkg.DirectionNone for the Particle's Tile).
+	Pheromone(pType PheromoneType, dir pkg.Direction) float64
+	// FindPath returns the next step Direction towards the nearest reachable Tile of the {targetType} Material,
+	// the path length and false if there is no such Tile within the search range (long-range pathfinding).
+	FindPath(targetType MaterialType, mode PathMode) (dir pkg.Direction, dist int, found bool)
 
-	// AddGravity adds the vertical gravity force Vector to the Particle.
+	// AddGravity adds the local gravity force Vector to the Particle.
 	AddGravity() (isApplied bool)
-	// AddReverseGravity adds the reversed vertical gravity force Vector to the Particle.
+	// AddReverseGravity adds the reversed local gravity force Vector to the Particle.
 	AddReverseGravity() (isApplied bool)
-	// AddWind adds global wind force Vector.
+	// AddWind adds the local wind force Vector (the wind field value at the source Position).
 	AddWind() (isApplied bool)
+	// AddBuoyancy adds the buoyant force Vector (opposite to the local gravity) if the Particle is (partially) submerged.
+	// The force is defined by the surrounding liquid to the Particle density ratio and the liquid neighbours share.
+	AddBuoyancy() (isApplied bool)
 
 	// ReplaceSelf replaces the Particle with a new one.
 	ReplaceSelf(newMaterial Material) (flagIn bool)
+	// ReplaceSelfWithState same as ReplaceSelf, but sets the new Particle initial state params.
+	ReplaceSelfWithState(newMaterial Material, state ParticleState) (flagIn bool)
+	// Explode blasts a circle area around the Particle (the Particle is consumed).
+	// Movable Particles are pushed outward, weak ones are damaged / destroyed, the area is heated and set on Fire / Smoke.
+	// The blast effect decreases with the distance and is blocked by obstacles (unmovable Particles stop it).
+	Explode(radius int, impulse, heat, destructionHealth float64) (flagIn bool)
 	// UpdateStateParam updates the internal Particle state param.
 	UpdateStateParam(paramKey string, paramValue int) (flagIn bool)
 	// AddSelfForce adds a new force to the Particle.
@@ -146,15 +297,27 @@ type TileEnvironment interface {
 	DampSelfHealth(step float64) (flagIn bool)
 	// RemoveSelfHealthDamps removes previously added Particle health reduction Actions.
 	RemoveSelfHealthDamps() (flagIn bool)
+	// DepositPheromone adds the pheromone amount to the Particle's Tile (the scent trail stays when the Particle moves away).
+	DepositPheromone(pType PheromoneType, amount float64) (flagIn bool)
 
 	// AddNewNeighbourTile adds a new neighbour Particle.
 	// Candidate is selected randomly from empty neighbours matching the filter.
 	// {dirFilters} filter includes candidates IN directions.
 	AddNewNeighbourTile(newMaterial Material, dirFilters []pkg.Direction) (isApplied bool)
+	// AddNewNeighbourTileWithState same as AddNewNeighbourTile, but sets the new Particle initial state params.
+	AddNewNeighbourTileWithState(newMaterial Material, dirFilters []pkg.Direction, state ParticleState) (isApplied bool)
 	// ReplaceNeighbourTile replaces a neighbour Tile with a new Particle.
 	// Candidate is selected randomly from non-empty neighbours matching the filter.
 	// {flagFilters} filter includes candidates WITH flags.
 	ReplaceNeighbourTile(newMaterial Material, flagFilters []MaterialFlag) (isApplied bool)
+	// ReplaceNeighbourTileByType replaces a neighbour Tile with a new Particle.
+	// Candidate is selected randomly from non-empty neighbours matching the filter.
+	// {typeFilters} filter includes candidates MATCHING types.
+	ReplaceNeighbourTileByType(newMaterial Material, typeFilters []MaterialType) (isApplied bool)
+	// ReplaceNeighbourTileByTypeWithState same as ReplaceNeighbourTileByType, but sets the new Particle initial state params.
+	ReplaceNeighbourTileByTypeWithState(newMaterial Material, typeFilters []MaterialType, state ParticleState) (isApplied bool)
+	// UpdateNeighbourStateParam updates the internal state param of the neighbour Particle in the {dir} direction.
+	UpdateNeighbourStateParam(dir pkg.Direction, paramKey string, paramValue int) (isApplied bool)
 	// AddNewNeighbourTileGrassStyle adds a new grass-like neighbour.
 	// Candidate select criteria:
 	//   - three close empty Tiles (for ex.: Top-Left, Top and Top-Right);
@@ -165,6 +328,11 @@ type TileEnvironment interface {
 	// {typeFilters} filter includes candidates MATCHING types.
 	// {flagFilters} filter includes candidates WITH flags.
 	DampNeighboursHealthByFlag(step float64, typeFilters []MaterialType, flagFilters []MaterialFlag) (neighboursAffectedCnt int)
+	// MoveTileWithPressure moves the pressurized Particle to an empty neighbour (liquids and gases only).
+	// Liquid moves up (preferred) or sideways if its Tile pressure is higher than the connected liquid free surface one.
+	// Gas moves to any direction if it is surrounded by other gas Particles.
+	// The move chance is defined by the Material flow properties.
+	MoveTileWithPressure() (isApplied bool)
 	// MoveTileWithNeighboursGasStyle moves the Particle up for gas-like Materials.
 	// Move to a randomly selected empty Tile in the upper direction sector (Top-Left + Top + Top-Right).
 	MoveTileWithNeighboursGasStyle() (isApplied bool)
@@ -179,10 +347,19 @@ type TileEnvironment interface {
 		typeFilters []MaterialType, typeIn bool,
 		flagFilters []MaterialFlag, flagIn bool,
 	) (tiles []*Tile, tileDirs []pkg.Direction)
+	// IsSurroundedByLiquid checks if the Particle is submerged: there are no empty neighbours and at least one of them is a liquid.
+	IsSurroundedByLiquid() bool
 
 	// AddNewTileInRange adds a new Particle in a circle range.
 	// Candidate is selected randomly from empty tiles.
 	AddNewTileInRange(newMaterial Material) bool
+	// AddNewTileInRangeWithState same as AddNewTileInRange, but sets the new Particle initial state params.
+	AddNewTileInRangeWithState(newMaterial Material, state ParticleState) bool
+	// ConsumeTileInRange kills a Particle (prey) within the {distance} absorbing its health.
+	// Candidate is selected randomly from non-empty tiles matching the filter.
+	// {typeFilters} filter includes candidates MATCHING types.
+	// {absorbK} defines the prey health part the Particle gains.
+	ConsumeTileInRange(distance float64, typeFilters []MaterialType, absorbK float64) (isApplied bool)
 	// AddForceInRange adds a force Vector in a circle range.
 	// If {mag} is LT 0, force is reflected.
 	// Non-empty candidates are selected by NOT filter.
@@ -246,4 +423,9 @@ type CollisionEnvironment interface {
 
 	// SwapSourceTarget adds Actions that swaps source and target Particles.
 	SwapSourceTarget() bool
+	// DisplaceByDensity adds Actions that swaps source and target Particles if the source sinks / floats through the target.
+	// Material mass is used as a density, the target must be a liquid / gas.
+	DisplaceByDensity() bool
+	// SwimThrough adds Actions that swaps source and target Particles if the source is a swimmer moving through a liquid target.
+	SwimThrough() bool
 }
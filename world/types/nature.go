@@ -0,0 +1,173 @@
+package types
+
+import (
+	"fmt"
+)
+
+// NaturePreset defines a predefined NatureConfig (weather).
+type NaturePreset int
+
+const (
+	// NaturePresetDefault is a moderate weather: rare clouds and a light wind.
+	NaturePresetDefault NaturePreset = iota
+	// NaturePresetCalm is a still weather: rare clouds and no wind.
+	NaturePresetCalm
+	// NaturePresetRainy is a wet weather: a lot of clouds and a light wind.
+	NaturePresetRainy
+	// NaturePresetStormy is a wild weather: a lot of clouds, a strong frequently changing wind and lightning strikes.
+	NaturePresetStormy
+	// NaturePresetDry is a dry weather: no clouds, a light wind and warm seasons (no frost).
+	NaturePresetDry
+	// NaturePresetWinter is an endless winter: a lot of clouds (snowfall) and a light wind.
+	NaturePresetWinter
+)
+
+// AllNaturePresets is a list of all known NaturePresets (in the switch order).
+var AllNaturePresets = []NaturePreset{NaturePresetDefault, NaturePresetCalm, NaturePresetRainy, NaturePresetStormy, NaturePresetDry, NaturePresetWinter}
+
+func (p NaturePreset) String() string {
+	switch p {
+	case NaturePresetDefault:
+		return "Weather"
+	case NaturePresetCalm:
+		return "Calm"
+	case NaturePresetRainy:
+		return "Rainy"
+	case NaturePresetStormy:
+		return "Stormy"
+	case NaturePresetDry:
+		return "Dry"
+	case NaturePresetWinter:
+		return "Winter"
+	}
+
+	return ""
+}
+
+// Season defines a season of the year cycle.
+type Season int
+
+const (
+	SeasonSpring Season = iota
+	SeasonSummer
+	SeasonAutumn
+	SeasonWinter
+)
+
+// SeasonsNum defines the number of seasons in a year cycle.
+const SeasonsNum = 4
+
+func (s Season) String() string {
+	switch s {
+	case SeasonSpring:
+		return "Spring"
+	case SeasonSummer:
+		return "Summer"
+	case SeasonAutumn:
+		return "Autumn"
+	case SeasonWinter:
+		return "Winter"
+	}
+
+	return ""
+}
+
+// NatureConfig defines the nature effects parameters.
+type NatureConfig struct {
+	CloudsPeriod     int     // number of processing rounds between clouds spawns
+	CloudsChance     float64 // Steam spawn chance per Tile of the clouds area
+	CloudsHeight     float64 // clouds area height (the Map height share from the top)
+	WindChangePeriod int     // number of processing rounds between the global wind changes
+	WindChance       float64 // chance of the wind to blow after a change (otherwise it is calm)
+	WindMagMax       float64 // max global wind magnitude (the actual one is random)
+	DayLength        int     // day / night cycle length [processing rounds] (0 - always a day)
+	LightningChance  float64 // lightning strike chance per processing round
+	Seed             int64   // random events (lightning) generator seed (0 - random)
+	// Seasons
+	SeasonLength      int     // single season length [processing rounds] (0 - the start season lasts forever)
+	StartSeason       Season  // the initial season (starts from its middle)
+	SummerTemperature float64 // ambient temperature in the middle of the summer (the warmest one)
+	WinterTemperature float64 // ambient temperature in the middle of the winter (the coldest one)
+}
+
+// NewNatureConfig returns the NatureConfig for the preset.
+func NewNatureConfig(preset NaturePreset) NatureConfig {
+	c := NatureConfig{
+		CloudsPeriod:     60,
+		CloudsChance:     1.0 / 1500.0,
+		CloudsHeight:     1.0 / 8.0,
+		WindChangePeriod: 500,
+		WindChance:       0.4,
+		WindMagMax:       0.1,
+		DayLength:        3000,
+		//
+		SeasonLength:      6000,
+		StartSeason:       SeasonSummer,
+		SummerTemperature: 25.0,
+		WinterTemperature: -10.0,
+	}
+
+	switch preset {
+	case NaturePresetCalm:
+		c.CloudsChance = 1.0 / 3000.0
+		c.WindChance = 0.0
+	case NaturePresetRainy:
+		c.CloudsPeriod = 20
+		c.CloudsChance = 1.0 / 200.0
+		c.CloudsHeight = 1.0 / 6.0
+		c.WindMagMax = 0.05
+	case NaturePresetStormy:
+		c.CloudsPeriod = 20
+		c.CloudsChance = 1.0 / 300.0
+		c.WindChangePeriod = 150
+		c.WindChance = 0.9
+		c.WindMagMax = 0.3
+		c.LightningChance = 0.01
+	case NaturePresetDry:
+		c.CloudsChance = 0.0
+		c.WindChance = 0.6
+		c.SummerTemperature = 35.0
+		c.WinterTemperature = 10.0
+	case NaturePresetWinter:
+		c.CloudsPeriod = 20
+		c.CloudsChance = 1.0 / 300.0
+		c.WindMagMax = 0.05
+		c.SeasonLength = 0
+		c.StartSeason = SeasonWinter
+	}
+
+	return c
+}
+
+// Validate checks the config.
+func (c NatureConfig) Validate() error {
+	if c.CloudsPeriod <= 0 || c.WindChangePeriod <= 0 {
+		return fmt.Errorf("cloudsPeriod / windChangePeriod: must be GT 0")
+	}
+	if c.CloudsChance < 0.0 || c.CloudsChance > 1.0 || c.WindChance < 0.0 || c.WindChance > 1.0 {
+		return fmt.Errorf("cloudsChance / windChance: must be in [0.0, 1.0]")
+	}
+	if c.CloudsHeight < 0.0 || c.CloudsHeight > 1.0 {
+		return fmt.Errorf("cloudsHeight: must be in [0.0, 1.0]")
+	}
+	if c.WindMagMax < 0.0 {
+		return fmt.Errorf("windMagMax: must be GTE 0")
+	}
+	if c.LightningChance < 0.0 || c.LightningChance > 1.0 {
+		return fmt.Errorf("lightningChance: must be in [0.0, 1.0]")
+	}
+	if c.DayLength < 0 {
+		return fmt.Errorf("dayLength: must be GTE 0")
+	}
+	if c.SeasonLength < 0 {
+		return fmt.Errorf("seasonLength: must be GTE 0")
+	}
+	if c.StartSeason < SeasonSpring || c.StartSeason > SeasonWinter {
+		return fmt.Errorf("startSeason: unknown")
+	}
+	if c.WinterTemperature > c.SummerTemperature {
+		return fmt.Errorf("winterTemperature: must be LTE summerTemperature")
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package types
+
+// OverlayType defines a Map state overlay rendered on top of Particles.
+type OverlayType int
+
+const (
+	OverlayTypeNone OverlayType = iota
+	OverlayTypeTemperature
+	OverlayTypePressure
+	OverlayTypeWind
+	OverlayTypeLight
+	OverlayTypePheromone
+)
+
+// AllOverlayTypes is a list of all known OverlayTypes (in the switch order).
+var AllOverlayTypes = []OverlayType{OverlayTypeNone, OverlayTypeTemperature, OverlayTypePressure, OverlayTypeWind, OverlayTypeLight, OverlayTypePheromone}
+
+func (t OverlayType) String() string {
+	switch t {
+	case OverlayTypeNone:
+		return "Overlay"
+	case OverlayTypeTemperature:
+		return "Heat"
+	case OverlayTypePressure:
+		return "Pressure"
+	case OverlayTypeWind:
+		return "Wind"
+	case OverlayTypeLight:
+		return "Light"
+	case OverlayTypePheromone:
+		return "Scent"
+	}
+
+	return ""
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 	"image/color"
+	"math"
 
 	"github.com/itiky/goPixelWorld/pkg"
 )
@@ -12,18 +13,22 @@ var lastParticleID uint64 = 0
 
 // Particle internal state parameter keys.
 const (
-	// ParticleStateParamSteady parameter defines a counter which is incremented if a Particle didn't move.
+	// ParticleStateParamSteady parameter defines a counter which is incremented if a Particle wanted to move, but didn't.
 	ParticleStateParamSteady = "steady"
 )
 
 type (
 	// Particle holds a single world object state (a pixel).
 	Particle struct {
-		id       uint64        // unique ID
-		material Material      // Particle's Material
-		forceVec pkg.Vector    // current force Vector
-		health   float64       // current health state
-		state    ParticleState // map of internal state counters
+		id       uint64                  // unique ID
+		material Material                // Particle's Material
+		forceVec pkg.Vector              // current force Vector
+		offsetX  float64                 // sub-Tile position remainder (accumulated fractional velocity)
+		offsetY  float64                 // sub-Tile position remainder (accumulated fractional velocity)
+		health   float64                 // current health state
+		state    ParticleState           // map of internal state counters
+		bodyID   int                     // rigid body ID this Particle is a member of (0 if none)
+		bonds    map[uint64]ParticleBond // bonded Particle ID -> bond (nil if none)
 	}
 
 	ParticleState map[string]int
@@ -55,6 +60,54 @@ func (p *Particle) ForceVector() pkg.Vector {
 	return p.forceVec
 }
 
+// CopyWithForce returns a shallow Particle copy with a different force Vector.
+// Used to simulate the Particle state, the internal state map is shared with the original.
+func (p *Particle) CopyWithForce(force pkg.Vector) *Particle {
+	pCopy := *p
+	pCopy.forceVec = force
+
+	return &pCopy
+}
+
+// BodyID returns the rigid body ID this Particle is a member of (0 if none).
+// A rigid body member is moved by the body, not by its own force Vector.
+func (p *Particle) BodyID() int {
+	return p.bodyID
+}
+
+// SetBodyID sets the rigid body ID.
+func (p *Particle) SetBodyID(id int) {
+	p.bodyID = id
+}
+
+// Offset returns the sub-Tile position remainder (accumulated fractional velocity).
+func (p *Particle) Offset() (float64, float64) {
+	return p.offsetX, p.offsetY
+}
+
+// Bonds returns the bonded Particle ID -> bond mapping (must not be modified).
+func (p *Particle) Bonds() map[uint64]ParticleBond {
+	return p.bonds
+}
+
+// HasBonds checks if the Particle is bonded to any other one.
+func (p *Particle) HasBonds() bool {
+	return len(p.bonds) > 0
+}
+
+// AddBond adds / updates a bond to another Particle.
+func (p *Particle) AddBond(particleID uint64, bond ParticleBond) {
+	if p.bonds == nil {
+		p.bonds = make(map[uint64]ParticleBond)
+	}
+	p.bonds[particleID] = bond
+}
+
+// RemoveBond removes a bond to another Particle (if exists).
+func (p *Particle) RemoveBond(particleID uint64) {
+	delete(p.bonds, particleID)
+}
+
 // Health returns the current health state.
 func (p *Particle) Health() float64 {
 	return p.health
@@ -96,18 +149,32 @@ func (p *Particle) DecStateParam(key string) {
 }
 
 // OnMove updates the internal state, dropping its steady param to 0 (it is moving right now).
-func (p *Particle) OnMove() {
+// The sub-Tile offset is reduced by the actual move delta keeping only the fractional part (intention is fulfilled).
+func (p *Particle) OnMove(dx, dy int) {
 	p.SetStateParam(ParticleStateParamSteady, 0)
+
+	p.offsetX = fractional(p.offsetX - float64(dx))
+	p.offsetY = fractional(p.offsetY - float64(dy))
 }
 
-// UpdateState updates the internal state parameters.
-// Drops the force Vector if Particle is not moving (solves a huge accumulated force issue).
+// UpdateState updates the internal state parameters and accumulates the force Vector into the sub-Tile offset.
+// So a slow Particle (force magnitude < 1.0) moves once per multiple rounds.
+// The steady param is incremented only if the previous move intention (a whole Tile offset) wasn't fulfilled.
+// Drops the force Vector if Particle is blocked for a while (solves a huge accumulated force issue).
 func (p *Particle) UpdateState() {
-	steadyCnt := p.IncStateParam(ParticleStateParamSteady)
-	if steadyCnt > 10 {
-		p.SetStateParam(ParticleStateParamSteady, 0)
-		p.forceVec = pkg.NewVector(0, 0)
+	if math.Abs(p.offsetX) >= 1.0 || math.Abs(p.offsetY) >= 1.0 {
+		p.offsetX, p.offsetY = fractional(p.offsetX), fractional(p.offsetY)
+
+		steadyCnt := p.IncStateParam(ParticleStateParamSteady)
+		if steadyCnt > 10 {
+			p.SetStateParam(ParticleStateParamSteady, 0)
+			p.forceVec = pkg.NewVector(0, 0)
+			p.offsetX, p.offsetY = 0.0, 0.0
+		}
 	}
+
+	p.offsetX += p.forceVec.X()
+	p.offsetY += p.forceVec.Y()
 }
 
 // AddForce adds a new Vector to the force Vector.
@@ -155,6 +222,11 @@ func (p *Particle) limitForce() {
 	}
 }
 
+// fractional returns the fractional part of a value (keeping the sign).
+func fractional(v float64) float64 {
+	return v - math.Trunc(v)
+}
+
 // nextParticleID returns the next unique Particle ID.
 func nextParticleID() uint64 {
 	lastParticleID++
@@ -0,0 +1,24 @@
+package types
+
+// PathMode defines which Tiles an agent can travel through while following a path.
+type PathMode int
+
+const (
+	PathModeWalk PathMode = iota // empty Tiles with a solid support in the local gravity direction (walkable surfaces)
+	PathModeFly                  // any empty Tiles
+	PathModeSwim                 // liquid Tiles
+	PathModesNum
+)
+
+func (m PathMode) String() string {
+	switch m {
+	case PathModeWalk:
+		return "Walk"
+	case PathModeFly:
+		return "Fly"
+	case PathModeSwim:
+		return "Swim"
+	}
+
+	return ""
+}
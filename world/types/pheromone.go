@@ -0,0 +1,25 @@
+package types
+
+// PheromoneType defines a pheromone (a scent trail) kind.
+// Each kind is a separate per-Tile scalar field which diffuses and evaporates with time.
+type PheromoneType int
+
+const (
+	PheromoneTypeFood PheromoneType = iota // leads to a food source (laid by Ants carrying food)
+	PheromoneTypeHome                      // leads to a nest (laid by the nest and Ants searching for food)
+	PheromoneTypesNum
+)
+
+// AllPheromoneTypes is a list of all known PheromoneTypes.
+var AllPheromoneTypes = []PheromoneType{PheromoneTypeFood, PheromoneTypeHome}
+
+func (t PheromoneType) String() string {
+	switch t {
+	case PheromoneTypeFood:
+		return "Food"
+	case PheromoneTypeHome:
+		return "Home"
+	}
+
+	return ""
+}
@@ -0,0 +1,49 @@
+package types
+
+import (
+	"math"
+)
+
+// PopulationStats defines the living species population snapshot.
+type PopulationStats struct {
+	Round          int                         // processing round the snapshot was taken at
+	BugsCount      int                         // number of Bugs (prey)
+	PredatorsCount int                         // number of Predators
+	BugTraits      [BugTraitsNum]BugTraitStats // per Bug trait distribution
+}
+
+// NewPopulationStats builds the population snapshot from the Bug genomes and the Predators number.
+func NewPopulationStats(round int, genomes []BugGenome, predatorsCnt int) PopulationStats {
+	stats := PopulationStats{
+		Round:          round,
+		BugsCount:      len(genomes),
+		PredatorsCount: predatorsCnt,
+	}
+	if len(genomes) == 0 {
+		return stats
+	}
+
+	for _, t := range AllBugTraits {
+		minV, maxV := t.Range()
+		traitStats := BugTraitStats{Min: math.MaxFloat64, Max: -math.MaxFloat64}
+		for _, g := range genomes {
+			v := g[t]
+			traitStats.Mean += v
+			traitStats.Min = math.Min(traitStats.Min, v)
+			traitStats.Max = math.Max(traitStats.Max, v)
+
+			bin := int((v - minV) / (maxV - minV) * BugTraitHistogramBins)
+			if bin < 0 {
+				bin = 0
+			}
+			if bin >= BugTraitHistogramBins {
+				bin = BugTraitHistogramBins - 1
+			}
+			traitStats.Histogram[bin]++
+		}
+		traitStats.Mean /= float64(len(genomes))
+		stats.BugTraits[t] = traitStats
+	}
+
+	return stats
+}
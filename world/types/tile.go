@@ -47,15 +47,15 @@ func (t *Tile) HasParticle() bool {
 	return t.Particle != nil
 }
 
-// TargetTile calculates the target Tile based the Particle force Vector.
+// TargetTile calculates the target Tile based the Particle sub-Tile offset (accumulated force Vector).
 func (t *Tile) TargetTile() *Tile {
 	if !t.HasParticle() {
 		return nil
 	}
 
 	targetPos := NewPosition(
-		t.Pos.X+int(t.Particle.forceVec.X()),
-		t.Pos.Y+int(t.Particle.forceVec.Y()),
+		t.Pos.X+int(t.Particle.offsetX),
+		t.Pos.Y+int(t.Particle.offsetY),
 	)
 	if targetPos.Equal(t.Pos) {
 		return nil
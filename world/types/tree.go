@@ -0,0 +1,30 @@
+package types
+
+// TreeGenome defines tree growth parameters.
+// A Seed keeps the genome, the grown tree parts and the Seeds it drops inherit it.
+type TreeGenome struct {
+	TrunkHeight   int     // max trunk height [Tiles]
+	BranchChance  float64 // chance of a new branch per trunk growth step
+	BranchLength  int     // max branch length [Tiles]
+	CanopyRadius  int     // leaves canopy radius around a grown trunk / branch end [Tiles]
+	GrowthChance  float64 // chance of a growth step per processing round
+	SeedChance    float64 // chance of dropping a new Seed per processing round (by a grown trunk / branch end)
+	MinLight      float64 // min light level at the trunk top to grow and survive
+	DarknessLimit int     // number of processing rounds in the dark (day rounds compensate) to die
+	DroughtLimit  int     // number of processing rounds without Water (at the tree root) to die
+}
+
+// DefaultTreeGenome returns the default TreeGenome.
+func DefaultTreeGenome() TreeGenome {
+	return TreeGenome{
+		TrunkHeight:   14,
+		BranchChance:  0.3,
+		BranchLength:  5,
+		CanopyRadius:  3,
+		GrowthChance:  0.05,
+		SeedChance:    0.0005,
+		MinLight:      0.05,
+		DarknessLimit: 2000,
+		DroughtLimit:  3000,
+	}
+}
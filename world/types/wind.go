@@ -0,0 +1,59 @@
+package types
+
+import (
+	"fmt"
+)
+
+// WindConfig defines the wind field parameters.
+// The field is a coarse grid of wind Vectors: the global (base) wind plus a smooth noise turbulence,
+// upward drafts and gusts. Tiles behind an unmovable obstacle (upwind) are sheltered.
+type WindConfig struct {
+	CellSize        int     // field grid cell size [Tiles]
+	Turbulence      float64 // max noise wind magnitude added to the base wind
+	NoiseScale      float64 // noise spatial frequency (per cell), the lower the value, the larger the wind patterns are
+	NoiseSpeed      float64 // noise change speed (per processing round)
+	Updraft         float64 // max upward wind magnitude (updrafts are noise based columns)
+	GustChance      float64 // chance of a gust to start (per processing round)
+	GustStrength    float64 // gust peak wind multiplier
+	GustDuration    int     // gust duration [processing rounds]
+	ShelterDistance int     // max distance to an upwind obstacle that shelters a Tile [Tiles]
+}
+
+// DefaultWindConfig returns the default wind field parameters.
+func DefaultWindConfig() WindConfig {
+	return WindConfig{
+		CellSize:        8,
+		Turbulence:      0.04,
+		NoiseScale:      0.25,
+		NoiseSpeed:      0.01,
+		Updraft:         0.05,
+		GustChance:      0.002,
+		GustStrength:    3.0,
+		GustDuration:    90,
+		ShelterDistance: 10,
+	}
+}
+
+// Validate checks the config.
+func (c WindConfig) Validate() error {
+	if c.CellSize <= 0 {
+		return fmt.Errorf("cellSize: must be GT 0")
+	}
+	if c.Turbulence < 0.0 || c.Updraft < 0.0 {
+		return fmt.Errorf("turbulence / updraft: must be GTE 0")
+	}
+	if c.NoiseScale <= 0.0 || c.NoiseSpeed < 0.0 {
+		return fmt.Errorf("noiseScale / noiseSpeed: must be GT 0 / GTE 0")
+	}
+	if c.GustChance < 0.0 || c.GustChance > 1.0 {
+		return fmt.Errorf("gustChance: must be in [0.0, 1.0]")
+	}
+	if c.GustStrength < 1.0 || c.GustDuration < 0 {
+		return fmt.Errorf("gustStrength / gustDuration: must be GTE 1 / GTE 0")
+	}
+	if c.ShelterDistance < 0 {
+		return fmt.Errorf("shelterDistance: must be GTE 0")
+	}
+
+	return nil
+}
@@ -0,0 +1,237 @@
+package world
+
+import (
+	"image/color"
+	"math"
+	"math/rand"
+
+	"github.com/itiky/goPixelWorld/pkg"
+	"github.com/itiky/goPixelWorld/world/closerange"
+	"github.com/itiky/goPixelWorld/world/types"
+)
+
+const (
+	// windShelterPeriod defines the number of processing rounds between obstacle shelter recalculations.
+	windShelterPeriod = 10
+	// windArrowScale defines the overlay arrow length per wind magnitude unit [Tiles].
+	windArrowScale = 40.0
+)
+
+// windField keeps the wind field state.
+type windField struct {
+	config         types.WindConfig
+	seed           int64       // noise seed
+	cellsX, cellsY int         // grid size [cells]
+	velX, velY     [][]float64 // wind Vector projections per cell
+	shelter        [][]float64 // per Tile wind share [0.0, 1.0] (obstacles shelter Tiles behind them)
+	time           float64     // noise time
+	gustLeft       int         // the current gust remaining duration [processing rounds] (0 if there is no gust)
+	round          int         // processing rounds counter
+}
+
+// newWindField creates a new windField for the grid.
+func newWindField(config types.WindConfig, width, height int) *windField {
+	f := windField{
+		config: config,
+		seed:   rand.Int63(),
+		cellsX: (width + config.CellSize - 1) / config.CellSize,
+		cellsY: (height + config.CellSize - 1) / config.CellSize,
+	}
+
+	f.velX, f.velY = make([][]float64, f.cellsX), make([][]float64, f.cellsX)
+	for x := 0; x < f.cellsX; x++ {
+		f.velX[x], f.velY[x] = make([]float64, f.cellsY), make([]float64, f.cellsY)
+	}
+
+	f.shelter = make([][]float64, width)
+	for x := 0; x < width; x++ {
+		f.shelter[x] = make([]float64, height)
+		for y := 0; y < height; y++ {
+			f.shelter[x][y] = 1.0
+		}
+	}
+
+	return &f
+}
+
+// processWind evolves the wind field for the next processing round.
+// Cell Vectors are the base (global) wind plus the noise turbulence and updrafts, a gust amplifies all of them.
+func (m *Map) processWind() {
+	f := m.wind
+	if f == nil {
+		return
+	}
+
+	if m.monitor != nil {
+		defer m.monitor.TrackOpDuration("Map.processWind")()
+	}
+
+	cfg := f.config
+	f.time += cfg.NoiseSpeed
+	f.round++
+
+	// Gust: a smooth rise and fall of the wind strength
+	gustK := 1.0
+	if f.gustLeft > 0 {
+		progress := 1.0 - float64(f.gustLeft)/float64(cfg.GustDuration)
+		gustK += (cfg.GustStrength - 1.0) * math.Sin(math.Pi*progress)
+		f.gustLeft--
+	} else if cfg.GustDuration > 0 && pkg.RollChance(cfg.GustChance) {
+		f.gustLeft = cfg.GustDuration
+	}
+
+	baseVec := closerange.GetWind()
+	for cx := 0; cx < f.cellsX; cx++ {
+		for cy := 0; cy < f.cellsY; cy++ {
+			nx, ny := float64(cx)*cfg.NoiseScale, float64(cy)*cfg.NoiseScale
+			turbX := cfg.Turbulence * pkg.ValueNoise3(nx, ny, f.time, f.seed)
+			turbY := cfg.Turbulence * pkg.ValueNoise3(nx, ny, f.time, f.seed+1)
+			updraft := cfg.Updraft * math.Max(0.0, pkg.ValueNoise3(nx, ny, f.time, f.seed+2))
+
+			f.velX[cx][cy] = gustK * (baseVec.X() + turbX)
+			f.velY[cx][cy] = gustK * (baseVec.Y() + turbY - updraft)
+		}
+	}
+
+	if f.round%windShelterPeriod == 1 {
+		m.updateWindShelter()
+	}
+}
+
+// updateWindShelter recalculates the per Tile wind share.
+// A Tile is sheltered if there is an unmovable Particle (not a grid border) upwind within the shelter distance:
+// the closer the obstacle is, the weaker the wind is.
+func (m *Map) updateWindShelter() {
+	f := m.wind
+	maxDist := f.config.ShelterDistance
+
+	for x := 0; x < m.width; x++ {
+		for y := 0; y < m.height; y++ {
+			f.shelter[x][y] = 1.0
+
+			velX, velY := f.sample(x, y)
+			mag := math.Hypot(velX, velY)
+			if maxDist == 0 || mag == 0.0 {
+				continue
+			}
+			stepX, stepY := -velX/mag, -velY/mag
+
+			for dist := 1; dist <= maxDist; dist++ {
+				ux, uy := x+int(math.Round(stepX*float64(dist))), y+int(math.Round(stepY*float64(dist)))
+				if !m.isPositionValid(ux, uy) {
+					break
+				}
+
+				tile := m.getTile(ux, uy)
+				if !tile.HasParticle() || tile.Particle.BodyID() != 0 {
+					continue
+				}
+				if material := tile.Particle.Material(); material.IsFlagged(types.MaterialFlagIsUnmovable) && material.Type() != types.MaterialTypeBorder {
+					f.shelter[x][y] = float64(dist-1) / float64(maxDist)
+					break
+				}
+			}
+		}
+	}
+}
+
+// windAt returns the wind Vector at the Position.
+// Returns the global wind if the wind field is disabled.
+func (m *Map) windAt(x, y int) pkg.Vector {
+	if m.wind == nil {
+		return closerange.GetWind()
+	}
+
+	velX, velY := m.wind.sample(x, y)
+	shelter := m.wind.shelter[x][y]
+	velX, velY = velX*shelter, velY*shelter
+	if velX == 0.0 && velY == 0.0 {
+		return pkg.NewVector(0, 0)
+	}
+
+	return pkg.NewVectorByCoordinates(math.Hypot(velX, velY), 0, 0, velX, velY)
+}
+
+// sample returns the wind Vector projections at the Position (bilinear interpolation between cell centers).
+func (f *windField) sample(x, y int) (float64, float64) {
+	size := float64(f.config.CellSize)
+	fx, fy := (float64(x)+0.5)/size-0.5, (float64(y)+0.5)/size-0.5
+
+	clampCell := func(c, cellsNum int) int {
+		if c < 0 {
+			return 0
+		}
+		if c >= cellsNum {
+			return cellsNum - 1
+		}
+		return c
+	}
+
+	x0, y0 := int(math.Floor(fx)), int(math.Floor(fy))
+	tx, ty := fx-float64(x0), fy-float64(y0)
+	x1, y1 := clampCell(x0+1, f.cellsX), clampCell(y0+1, f.cellsY)
+	x0, y0 = clampCell(x0, f.cellsX), clampCell(y0, f.cellsY)
+
+	bilinear := func(v [][]float64) float64 {
+		top := v[x0][y0] + (v[x1][y0]-v[x0][y0])*tx
+		bottom := v[x0][y1] + (v[x1][y1]-v[x0][y1])*tx
+		return top + (bottom-top)*ty
+	}
+
+	return bilinear(f.velX), bilinear(f.velY)
+}
+
+// appendWindOverlay draws wind arrows (one per cell) to the overlay output buffer starting from the {pixelIdx}.
+// Returns the next free buffer index.
+func (m *Map) appendWindOverlay(pixelIdx int) int {
+	var (
+		bodyColor = color.NRGBA{R: 0xE0, G: 0xF0, B: 0xFF, A: 0xA0}
+		headColor = color.NRGBA{R: 0xFF, G: 0x40, B: 0x40, A: 0xE0}
+	)
+
+	cellSize := 8
+	if m.wind != nil {
+		cellSize = m.wind.config.CellSize
+	}
+
+	appendPixel := func(x, y int, c color.Color) {
+		if !m.isPositionValid(x, y) || pixelIdx >= len(m.procOverlayOutput)-1 {
+			return
+		}
+
+		m.procOverlayOutput[pixelIdx].Ready = true
+		m.procOverlayOutput[pixelIdx].PosX = x
+		m.procOverlayOutput[pixelIdx].PosY = y
+		m.procOverlayOutput[pixelIdx].ParticleColor = c
+		pixelIdx++
+	}
+
+	for x := cellSize / 2; x < m.width; x += cellSize {
+		for y := cellSize / 2; y < m.height; y += cellSize {
+			windVec := m.windAt(x, y)
+			length := math.Min(windVec.Magnitude()*windArrowScale, float64(cellSize-1))
+			if length < 1.0 {
+				appendPixel(x, y, bodyColor)
+				continue
+			}
+
+			start := types.Position{X: x, Y: y}
+			end := types.Position{
+				X: x + int(math.Round(windVec.X()/windVec.Magnitude()*length)),
+				Y: y + int(math.Round(windVec.Y()/windVec.Magnitude()*length)),
+			}
+
+			appendPixel(start.X, start.Y, bodyColor)
+			path := start.CreatePathTo(end, m.width, m.height)
+			for i, pos := range path {
+				if i == len(path)-1 {
+					appendPixel(pos.X, pos.Y, headColor)
+					continue
+				}
+				appendPixel(pos.X, pos.Y, bodyColor)
+			}
+		}
+	}
+
+	return pixelIdx
+}
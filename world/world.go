@@ -2,6 +2,7 @@ package world
 
 import (
 	"fmt"
+	"math/rand"
 	"sync"
 
 	"github.com/itiky/goPixelWorld/monitor"
@@ -20,6 +21,25 @@ type Map struct {
 	particles map[uint64]*types.Tile
 	// Position (coordinates) -> Tile mapping
 	grid [][]*types.Tile
+	// Per Tile temperature
+	heat *scalarField
+	// Per Tile pressure (fluids only)
+	pressure *scalarField
+	// Per Tile light level (sunlight reaching the Tile)
+	light *scalarField
+	// Per Tile pheromone levels by type (scent trails)
+	pheromones [types.PheromoneTypesNum]*scalarField
+	// Cached flow fields to the nearest target Material (agents pathfinding)
+	pathFinder *pathFinder
+	// Per Tile fluid body labels (pressure calculation buffer)
+	pressureLabels [][]int
+	// Per Tile liquid support states (pressure calculation buffer)
+	pressureSupport [][]liquidSupport
+	// Rigid bodies by ID
+	bodies     map[int]*rigidBody
+	lastBodyID int
+	// Bonded Particle IDs (might include already unbonded ones)
+	bondedParticles map[uint64]bool
 
 	/* Processing state */
 	// Tile workers input jobs queue (a Tile to process)
@@ -38,14 +58,61 @@ type Map struct {
 	procAckCh chan struct{}
 	// The next Map state to export
 	procOutput []types.Pixel
+	// Field workers input jobs queue (a grid stripe index to process)
+	procFieldJobCh chan int
+	// Field jobs counter
+	procFieldWorkerWG sync.WaitGroup
+	// Per grid stripe output actions queue
+	procFieldActions [][]types.Action
+	// The next Map overlay state to export
+	procOverlayOutput []types.Pixel
+
+	/* Gravity state */
+	gravity gravityField
+
+	/* Attraction state */
+	gravitonMode types.GravitonMode
+	// Attraction sources tree for the inverse-square modes (nil if there are no sources)
+	attractionTree *attractionNode
+
+	/* Wind state */
+	windConfig *types.WindConfig
+	// Wind field (nil if disabled: the global wind is applied everywhere)
+	wind *windField
+
+	/* Overlay state */
+	overlayType types.OverlayType
 
 	/* Input state */
 	inputActions []types.InputAction
+	// Actions generated by input handlers (applied first within the next processing round)
+	inputTileActions []types.Action
+	// The current bond tool stroke state (Particles painted within the stroke)
+	bondStrokeID          int
+	bondStrokeParticleIDs map[uint64]bool
+	bondStrokeLastPID     uint64
 
 	/* Nature state */
 	natureEnabled           bool
+	natureConfig            types.NatureConfig
 	natureCloudsTimeout     int
 	natureWindChangeTimeout int
+	natureRand              *rand.Rand // random events generator (seeded by the config)
+	// Day / night cycle state
+	dayTime          int     // the current day time [processing rounds]
+	daylight         float64 // the current daylight level [0.0, 1.0] (updated by the processing)
+	daylightExported float64 // daylight level of the last exported state
+	// Seasons state
+	yearTime    int          // the current year cycle time [processing rounds]
+	season      types.Season // the current season
+	ambientTemp float64      // the current ambient temperature (empty Tiles relax towards it)
+	// Lightning bolt paths struck during the last state export
+	lightningBolts [][]types.Position
+
+	/* Population state */
+	round         int                     // processing rounds counter
+	population    []types.PopulationStats // population snapshots history
+	bugGenomesBuf []types.BugGenome       // snapshot buffer
 
 	/* External services */
 	monitor *monitor.Keeper
@@ -76,9 +143,58 @@ func WithHeight(height int) MapOption {
 }
 
 // WithNatureEffects options enables nature effects like clouds, wind, etc.
-func WithNatureEffects() MapOption {
+// Use types.NewNatureConfig to get a preset config.
+func WithNatureEffects(config types.NatureConfig) MapOption {
 	return func(m *Map) error {
+		if err := config.Validate(); err != nil {
+			return fmt.Errorf("invalid nature config: %w", err)
+		}
+
 		m.natureEnabled = true
+		m.natureConfig = config
+		return nil
+	}
+}
+
+// WithGravitonMode option sets the Graviton / AntiGraviton attraction mode.
+func WithGravitonMode(mode types.GravitonMode) MapOption {
+	return func(m *Map) error {
+		if mode < types.GravitonModeCircle || mode > types.GravitonModeNBody {
+			return fmt.Errorf("invalid graviton mode: %d", mode)
+		}
+
+		m.gravitonMode = mode
+		return nil
+	}
+}
+
+// WithDirectionalGravity option sets the directional gravity angle (Pi/2 is down).
+func WithDirectionalGravity(angleRad float64) MapOption {
+	return func(m *Map) error {
+		m.gravity.mode = types.GravityModeDirectional
+		m.gravity.setAngle(angleRad)
+		return nil
+	}
+}
+
+// WithRadialGravity option enables the radial gravity towards the centers ("planets").
+// If no centers are provided, the grid center is used.
+func WithRadialGravity(centers ...types.Position) MapOption {
+	return func(m *Map) error {
+		m.gravity.mode = types.GravityModeRadial
+		m.gravity.setCenters(centers)
+		return nil
+	}
+}
+
+// WithWindField option enables the spatially varying wind field (turbulence, updrafts, gusts and obstacle shelters).
+func WithWindField(config types.WindConfig) MapOption {
+	return func(m *Map) error {
+		if err := config.Validate(); err != nil {
+			return fmt.Errorf("invalid wind config: %w", err)
+		}
+
+		m.windConfig = &config
 		return nil
 	}
 }
@@ -103,6 +219,7 @@ func NewMap(opts ...MapOption) (*Map, error) {
 		width:     200,
 		height:    200,
 		particles: make(map[uint64]*types.Tile),
+		gravity:   newGravityField(),
 	}
 	for _, opt := range opts {
 		if err := opt(&m); err != nil {
@@ -119,17 +236,26 @@ func NewMap(opts ...MapOption) (*Map, error) {
 	return &m, nil
 }
 
+// Daylight returns the ambient daylight level [0.0, 1.0] of the last exported state.
+func (m *Map) Daylight() float64 {
+	return m.daylightExported
+}
+
 // Size returns the grid size.
 func (m *Map) Size() (int, int) {
 	return m.width, m.height
 }
 
 // ExportState exports the current Map state.
+// {overlayFn} is optional and receives the current overlay state (if enabled).
 // Waits for the current processing round to end and starts a new one after the export is done.
-func (m *Map) ExportState(fn func(pixel types.TileI)) {
+func (m *Map) ExportState(fn func(pixel types.TileI), overlayFn func(pixel types.TileI)) {
 	// Wait for the previous processing round to finish
 	m.processingDone()
 	defer m.processingStart()
+	m.daylightExported = m.daylight
+	m.lightningBolts = m.lightningBolts[:0]
+	m.updatePopulation()
 
 	// Export
 	for i := 0; i < len(m.procOutput); i++ {
@@ -138,6 +264,14 @@ func (m *Map) ExportState(fn func(pixel types.TileI)) {
 		}
 		fn(m.procOutput[i])
 	}
+	if overlayFn != nil {
+		for i := 0; i < len(m.procOverlayOutput); i++ {
+			if !m.procOverlayOutput[i].Ready {
+				break
+			}
+			overlayFn(m.procOverlayOutput[i])
+		}
+	}
 
 	// Nature events
 	if m.natureEnabled {
@@ -154,6 +288,24 @@ func (m *Map) ExportState(fn func(pixel types.TileI)) {
 			m.handleRemoveParticlesInput(action)
 		case types.FlipGravityInputAction:
 			m.handleFlipGravityInput()
+		case types.SetOverlayInputAction:
+			m.handleSetOverlayInput(action)
+		case types.CreateBodyInputAction:
+			m.handleCreateBodyInput(action)
+		case types.SetGravitonModeInputAction:
+			m.handleSetGravitonModeInput(action)
+		case types.SetGravityModeInputAction:
+			m.handleSetGravityModeInput(action)
+		case types.RotateGravityInputAction:
+			m.handleRotateGravityInput(action)
+		case types.AddGravityCenterInputAction:
+			m.handleAddGravityCenterInput(action)
+		case types.ClearGravityCentersInputAction:
+			m.handleClearGravityCentersInput()
+		case types.SetNaturePresetInputAction:
+			m.handleSetNaturePresetInput(action)
+		case types.StrikeLightningInputAction:
+			m.handleStrikeLightningInput(action)
 		}
 	}
 	m.inputActions = m.inputActions[:0]
@@ -4,7 +4,6 @@ import (
 	"math/rand"
 
 	"github.com/itiky/goPixelWorld/pkg"
-	"github.com/itiky/goPixelWorld/world/closerange"
 	"github.com/itiky/goPixelWorld/world/types"
 )
 
@@ -20,6 +19,7 @@ func (m *Map) PushInputAction(action types.InputAction) {
 
 // handleCreateParticlesInput handles the CreateParticlesInputAction input action.
 func (m *Map) handleCreateParticlesInput(input types.CreateParticlesInputAction) {
+	var newTiles []*types.Tile
 	for _, pos := range types.PositionsInCircle(input.X, input.Y, input.Radius, true) {
 		// Skip out-of-range Positions
 		if !m.isPositionValid(pos.X, pos.Y) {
@@ -52,6 +52,11 @@ func (m *Map) handleCreateParticlesInput(input types.CreateParticlesInputAction)
 			)
 			tile.Particle.SetForce(forceVec)
 		}
+		newTiles = append(newTiles, tile)
+	}
+
+	if input.Bond != types.BondTypeNone {
+		m.createStrokeBonds(input.StrokeID, input.Bond, newTiles)
 	}
 }
 
@@ -75,5 +80,86 @@ func (m *Map) handleRemoveParticlesInput(input types.DeleteParticlesInputAction)
 
 // handleFlipGravityInput handles the FlipGravityInputAction input action.
 func (m *Map) handleFlipGravityInput() {
-	closerange.FlipGravity()
+	m.gravity.flip()
+}
+
+// handleSetGravityModeInput handles the SetGravityModeInputAction input action.
+func (m *Map) handleSetGravityModeInput(input types.SetGravityModeInputAction) {
+	m.gravity.mode = input.Mode
+}
+
+// handleRotateGravityInput handles the RotateGravityInputAction input action.
+func (m *Map) handleRotateGravityInput(input types.RotateGravityInputAction) {
+	m.gravity.rotate(input.Angle)
+}
+
+// handleAddGravityCenterInput handles the AddGravityCenterInputAction input action.
+func (m *Map) handleAddGravityCenterInput(input types.AddGravityCenterInputAction) {
+	if !m.isPositionValid(input.X, input.Y) {
+		return
+	}
+
+	m.gravity.addCenter(types.Position{X: input.X, Y: input.Y})
+}
+
+// handleClearGravityCentersInput handles the ClearGravityCentersInputAction input action.
+func (m *Map) handleClearGravityCentersInput() {
+	m.gravity.setCenters(nil)
+}
+
+// handleSetNaturePresetInput handles the SetNaturePresetInputAction input action.
+// Nature effects are enabled if they were not.
+func (m *Map) handleSetNaturePresetInput(input types.SetNaturePresetInputAction) {
+	m.setNatureConfig(types.NewNatureConfig(input.Preset))
+	m.natureEnabled = true
+}
+
+// handleStrikeLightningInput handles the StrikeLightningInputAction input action.
+func (m *Map) handleStrikeLightningInput(input types.StrikeLightningInputAction) {
+	if !m.isPositionValid(input.X, 0) {
+		return
+	}
+
+	m.strikeLightning(m.traceLightningBolt(m.natureRand, input.X))
+}
+
+// handleSetOverlayInput handles the SetOverlayInputAction input action.
+func (m *Map) handleSetOverlayInput(input types.SetOverlayInputAction) {
+	m.overlayType = input.Overlay
+}
+
+// handleSetGravitonModeInput handles the SetGravitonModeInputAction input action.
+func (m *Map) handleSetGravitonModeInput(input types.SetGravitonModeInputAction) {
+	m.gravitonMode = input.Mode
+}
+
+// handleCreateBodyInput handles the CreateBodyInputAction input action.
+// All stroke circle areas form a single body.
+// Only solid Particles (not a liquid / gas / fire) that are not members of other bodies are grouped.
+func (m *Map) handleCreateBodyInput(input types.CreateBodyInputAction) {
+	var tiles []*types.Tile
+	isGrouped := make(map[uint64]bool)
+	for _, strokePos := range input.Positions {
+		for _, pos := range types.PositionsInCircle(strokePos.X, strokePos.Y, input.Radius, true) {
+			// Skip out-of-range Positions
+			if !m.isPositionValid(pos.X, pos.Y) {
+				continue
+			}
+
+			// Skip empty Tiles, non-solid and already grouped Particles
+			tile := m.getTile(pos.X, pos.Y)
+			if !tile.HasParticle() || tile.Particle.BodyID() != 0 || isGrouped[tile.Particle.ID()] {
+				continue
+			}
+			material := tile.Particle.Material()
+			if material.Type() == types.MaterialTypeBorder || material.IsFlagged(types.MaterialFlagIsLiquid, types.MaterialFlagIsGas, types.MaterialFlagIsFire) {
+				continue
+			}
+
+			isGrouped[tile.Particle.ID()] = true
+			tiles = append(tiles, tile)
+		}
+	}
+
+	m.createBody(tiles, input.Pinned)
 }